@@ -0,0 +1,205 @@
+// Description: Package announcementsChecker contains a class that when started periodically checks a exchange for new announcements and posts a message in set message channels.
+package announcementsChecker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/time/rate"
+)
+
+// AnnouncementsChecker is a class that when started checks a exchange for new announcements and posts a message in set message channels.
+type AnnouncementsChecker struct {
+	source     exchanges.AnnouncementSource
+	stateStore store.Store
+	notifier   messaging.Notifier
+}
+
+// announcementsCatalog represents a announcement catalog that is checked separately.
+type announcementsCatalog struct {
+	name                        string // Name under which the seen announcements are stored.
+	retrieve                    func() ([]exchanges.Announcement, error)
+	retryPolicy                 *exchanges.RetryPolicy
+	lastAnnouncementWarningTime time.Time
+}
+
+// NewAnnouncementsChecker creates a new AnnouncementsChecker for a given announcement source.
+func NewAnnouncementsChecker(source exchanges.AnnouncementSource, stateStore store.Store, notifier messaging.Notifier) *AnnouncementsChecker {
+	return &AnnouncementsChecker{
+		source:     source,
+		stateStore: stateStore,
+		notifier:   notifier,
+	}
+}
+
+// catalogs returns the announcement catalogs of the announcement source.
+// NOTE: Sources that do not implement the exchanges.CatalogAnnouncementSource interface are checked as a single catalog.
+func (ac *AnnouncementsChecker) catalogs() (catalogs []*announcementsCatalog) {
+	catalogSource, ok := ac.source.(exchanges.CatalogAnnouncementSource)
+	if !ok {
+		return []*announcementsCatalog{{
+			name:                        ac.source.Name(),
+			retrieve:                    ac.source.RetrieveAnnouncements,
+			retryPolicy:                 exchanges.NewRetryPolicy(exchanges.DEFAULT_MIN_RETRY_BACKOFF, exchanges.DEFAULT_MAX_RETRY_BACKOFF),
+			lastAnnouncementWarningTime: time.Now(),
+		}}
+	}
+
+	// NOTE: The announcements of the legacy catalog are stored under the source name so that the announcements that were stored before the
+	// catalogs were checked separately are kept.
+	legacySource, hasLegacyCatalog := ac.source.(exchanges.LegacyCatalogSource)
+	for _, catalogID := range catalogSource.Catalogs() {
+		catalogID := catalogID
+		name := fmt.Sprintf("%s_%d", ac.source.Name(), catalogID)
+		if hasLegacyCatalog && catalogID == legacySource.LegacyCatalog() {
+			name = ac.source.Name()
+		}
+		catalogs = append(catalogs, &announcementsCatalog{
+			name: name,
+			retrieve: func() ([]exchanges.Announcement, error) {
+				return catalogSource.RetrieveCatalogAnnouncements(catalogID)
+			},
+			retryPolicy:                 exchanges.NewRetryPolicy(exchanges.DEFAULT_MIN_RETRY_BACKOFF, exchanges.DEFAULT_MAX_RETRY_BACKOFF),
+			lastAnnouncementWarningTime: time.Now(),
+		})
+	}
+	return catalogs
+}
+
+// retrieveAnnouncements retrieves the latest announcements of a announcement catalog.
+// NOTE: Waits the backoff of the error class if failed and throws warning every minute (or directly for non-transient errors).
+func (ac *AnnouncementsChecker) retrieveAnnouncements(catalog *announcementsCatalog) (announcements map[string]exchanges.Announcement) {
+	announcementsList, err := catalog.retrieve()
+	if err != nil {
+		backoff := catalog.retryPolicy.Failure(err)
+		errorClass := exchanges.ErrorClass(err)
+		if errorClass != exchanges.ERROR_TRANSIENT || time.Since(catalog.lastAnnouncementWarningTime) > time.Minute { // Only log every minute.
+			log.Printf("WARNING: Error retrieving %s announcements (%s, retrying in %v): %v", catalog.name, errorClass, backoff, err)
+			catalog.lastAnnouncementWarningTime = time.Now()
+		}
+		time.Sleep(backoff)
+		return announcements
+	}
+	catalog.retryPolicy.Success()
+
+	// Create announcements map.
+	announcements = make(map[string]exchanges.Announcement)
+	for _, announcement := range announcementsList {
+		announcements[announcement.Code] = announcement
+	}
+	return announcements
+}
+
+// announcementsCheck checks whether new announcements have been published in a catalog and which announcements were removed.
+func (ac *AnnouncementsChecker) announcementsCheck(catalog *announcementsCatalog, oldAnnouncementsCodes *[]string) (newAnnouncementsCodes []string, newAnnouncements map[string]exchanges.Announcement, removedAnnouncementsCodes []string) {
+	announcements := ac.retrieveAnnouncements(catalog)
+	if len(announcements) == 0 {
+		return nil, nil, nil
+	}
+
+	// Check if new announcements have been published or old announcements were removed.
+	announcementsCodes := maps.Keys(announcements)
+	newAnnouncementsCodes, removedAnnouncementsCodes = utils.DiffLists(*oldAnnouncementsCodes, announcementsCodes)
+	*oldAnnouncementsCodes = announcementsCodes
+
+	// Create new announcements map.
+	newAnnouncements = make(map[string]exchanges.Announcement)
+	for _, code := range newAnnouncementsCodes {
+		newAnnouncements[code] = announcements[code]
+	}
+
+	return newAnnouncementsCodes, newAnnouncements, removedAnnouncementsCodes
+}
+
+// storeOldAnnouncements stores the old announcement codes of a catalog in the state store.
+func (ac *AnnouncementsChecker) storeOldAnnouncements(catalog *announcementsCatalog, oldAnnouncements []string) {
+	err := ac.stateStore.StoreItems(store.ANNOUNCEMENTS, catalog.name, oldAnnouncements)
+	if err != nil {
+		log.Printf("WARNING: Error storing %s announcements: %v", catalog.name, err)
+	}
+}
+
+// storeAnnouncementHistory stores a announcement in the announcement history if supported by the state store.
+func (ac *AnnouncementsChecker) storeAnnouncementHistory(announcement exchanges.Announcement, firstSeen time.Time, sent bool) {
+	announcementStore, ok := ac.stateStore.(store.AnnouncementStore)
+	if !ok {
+		return
+	}
+
+	err := announcementStore.StoreAnnouncement(announcement, firstSeen, sent)
+	if err != nil {
+		log.Printf("WARNING: Error storing %s announcement history: %v", ac.source.Name(), err)
+	}
+}
+
+// check periodically checks a announcement catalog for new announcements and posts messages.
+func (ac *AnnouncementsChecker) check(catalog *announcementsCatalog, limiter *rate.Limiter) {
+	// Retrieve (old) announcements.
+	oldAnnouncements, err := ac.stateStore.RetrieveItems(store.ANNOUNCEMENTS, catalog.name)
+	if err != nil {
+		log.Fatalf("Error retrieving old %s announcements: %v", catalog.name, err)
+	}
+	if len(oldAnnouncements) == 0 { // Get from the exchange if no old announcements are stored.
+		announcements := ac.retrieveAnnouncements(catalog)
+		oldAnnouncements = maps.Keys(announcements)
+		ac.storeOldAnnouncements(catalog, oldAnnouncements)
+		for _, announcement := range announcements {
+			ac.storeAnnouncementHistory(announcement, time.Now(), false)
+		}
+	}
+
+	// Check the catalog for new announcements and post messages.
+	for {
+		limiter.Wait(context.Background()) // NOTE: This is to prevent the exchange from blocking the IP address.
+
+		// Check for new and removed announcements.
+		newAnnouncementsCodes, newAnnouncements, removedAnnouncementsCodes := ac.announcementsCheck(catalog, &oldAnnouncements)
+
+		// Log removed announcements.
+		// NOTE: Not posted since announcements are also removed when they drop out of the latest announcements.
+		for _, announcementCode := range removedAnnouncementsCodes {
+			log.Printf("Removed %s announcement: %s", catalog.name, announcementCode)
+		}
+
+		// Post messages.
+		for _, announcementCode := range newAnnouncementsCodes {
+			// Log announcement.
+			announcement := newAnnouncements[announcementCode]
+			firstSeen := time.Now()
+			if announcement.PublishDate.IsZero() {
+				log.Printf("New %s announcement: %s", catalog.name, announcement.Title)
+			} else {
+				log.Printf("New %s announcement: %s (detected after %v)", catalog.name, announcement.Title, firstSeen.Sub(announcement.PublishDate))
+			}
+			ac.storeAnnouncementHistory(announcement, firstSeen, true)
+
+			// Post messages.
+			ac.notifier.NotifyAnnouncement(announcement)
+
+			ac.storeOldAnnouncements(catalog, oldAnnouncements)
+		}
+	}
+}
+
+// Start starts the AnnouncementsChecker.
+// NOTE: The catalogs of the announcement source are checked concurrently and share the max request rate.
+func (ac *AnnouncementsChecker) Start(maxRate float64) {
+	limiter := rate.NewLimiter(rate.Limit(maxRate), 1)
+	var wg sync.WaitGroup
+	for _, catalog := range ac.catalogs() {
+		wg.Add(1)
+		go func(catalog *announcementsCatalog) {
+			defer wg.Done()
+			ac.check(catalog, limiter)
+		}(catalog)
+	}
+	wg.Wait()
+}
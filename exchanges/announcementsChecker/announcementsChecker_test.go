@@ -0,0 +1,110 @@
+// Description: Tests for the announcementsChecker package.
+
+package announcementsChecker
+
+import (
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// fakeAnnouncementSource is a announcement source that returns a fixed list of announcements.
+type fakeAnnouncementSource struct {
+	announcements []exchanges.Announcement
+}
+
+func (fas *fakeAnnouncementSource) Name() string {
+	return "Fake"
+}
+
+func (fas *fakeAnnouncementSource) RetrieveAnnouncements() ([]exchanges.Announcement, error) {
+	return fas.announcements, nil
+}
+
+// fakeCatalogAnnouncementSource is a announcement source that returns a fixed list of announcements per catalog.
+type fakeCatalogAnnouncementSource struct {
+	fakeAnnouncementSource
+	catalogsAnnouncements map[int64][]exchanges.Announcement
+}
+
+func (fcas *fakeCatalogAnnouncementSource) Catalogs() []int64 {
+	return []int64{48, 161}
+}
+
+func (fcas *fakeCatalogAnnouncementSource) RetrieveCatalogAnnouncements(catalogID int64) ([]exchanges.Announcement, error) {
+	return fcas.catalogsAnnouncements[catalogID], nil
+}
+
+// TestAnnouncementsCheck tests that the announcementsCheck function reports new announcements that push out old ones.
+func TestAnnouncementsCheck(t *testing.T) {
+	source := &fakeAnnouncementSource{announcements: []exchanges.Announcement{{Code: "b", Title: "B"}, {Code: "c", Title: "C"}}}
+	ac := NewAnnouncementsChecker(source, nil, nil)
+	oldAnnouncementsCodes := []string{"a", "b"}
+
+	newAnnouncementsCodes, newAnnouncements, removedAnnouncementsCodes := ac.announcementsCheck(ac.catalogs()[0], &oldAnnouncementsCodes)
+	if len(newAnnouncementsCodes) != 1 || newAnnouncementsCodes[0] != "c" {
+		t.Errorf("Expected %v, got %v", []string{"c"}, newAnnouncementsCodes)
+	}
+	if newAnnouncements["c"].Title != "C" {
+		t.Errorf("Expected %s, got %s", "C", newAnnouncements["c"].Title)
+	}
+	if len(removedAnnouncementsCodes) != 1 || removedAnnouncementsCodes[0] != "a" {
+		t.Errorf("Expected %v, got %v", []string{"a"}, removedAnnouncementsCodes)
+	}
+	if len(oldAnnouncementsCodes) != 2 {
+		t.Errorf("Expected length of 2, got %d", len(oldAnnouncementsCodes))
+	}
+}
+
+// TestCatalogsAnnouncementsCheck tests that the catalogs of a catalog announcement source are checked separately.
+func TestCatalogsAnnouncementsCheck(t *testing.T) {
+	source := &fakeCatalogAnnouncementSource{catalogsAnnouncements: map[int64][]exchanges.Announcement{
+		48:  {{Code: "a", CatalogName: "New Cryptocurrency Listing"}},
+		161: {{Code: "b", CatalogName: "Delisting"}},
+	}}
+	ac := NewAnnouncementsChecker(source, nil, nil)
+
+	catalogs := ac.catalogs()
+	if len(catalogs) != 2 {
+		t.Fatalf("Expected length of 2, got %d", len(catalogs))
+	}
+	if catalogs[0].name != "Fake_48" || catalogs[1].name != "Fake_161" {
+		t.Errorf("Expected %v, got %v", []string{"Fake_48", "Fake_161"}, []string{catalogs[0].name, catalogs[1].name})
+	}
+
+	oldAnnouncementsCodes := []string{"a"}
+	newAnnouncementsCodes, _, removedAnnouncementsCodes := ac.announcementsCheck(catalogs[0], &oldAnnouncementsCodes)
+	if len(newAnnouncementsCodes) != 0 || len(removedAnnouncementsCodes) != 0 {
+		t.Errorf("Expected no changes, got %v and %v", newAnnouncementsCodes, removedAnnouncementsCodes)
+	}
+	newAnnouncementsCodes, newAnnouncements, _ := ac.announcementsCheck(catalogs[1], &oldAnnouncementsCodes)
+	if len(newAnnouncementsCodes) != 1 || newAnnouncements["b"].CatalogName != "Delisting" {
+		t.Errorf("Expected %v, got %v", []string{"b"}, newAnnouncementsCodes)
+	}
+}
+
+// fakeLegacyCatalogAnnouncementSource is a catalog announcement source that checked catalog 48 before its catalogs were checked separately.
+type fakeLegacyCatalogAnnouncementSource struct {
+	*fakeCatalogAnnouncementSource
+}
+
+func (flcas *fakeLegacyCatalogAnnouncementSource) LegacyCatalog() int64 {
+	return 48
+}
+
+// TestLegacyCatalog tests that the announcements of the legacy catalog are stored under the source name.
+func TestLegacyCatalog(t *testing.T) {
+	source := &fakeLegacyCatalogAnnouncementSource{&fakeCatalogAnnouncementSource{catalogsAnnouncements: map[int64][]exchanges.Announcement{
+		48:  {{Code: "a"}},
+		161: {{Code: "b"}},
+	}}}
+	ac := NewAnnouncementsChecker(source, nil, nil)
+
+	catalogs := ac.catalogs()
+	if len(catalogs) != 2 {
+		t.Fatalf("Expected length of 2, got %d", len(catalogs))
+	}
+	if catalogs[0].name != "Fake" || catalogs[1].name != "Fake_161" {
+		t.Errorf("Expected %v, got %v", []string{"Fake", "Fake_161"}, []string{catalogs[0].name, catalogs[1].name})
+	}
+}
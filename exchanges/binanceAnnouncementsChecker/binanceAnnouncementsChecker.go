@@ -1,30 +1,43 @@
-// Description: Package binanceAnnouncementsChecker contains a class that when started checks the unofficial Binance announcements api for new announcements.
+// Description: Package binanceAnnouncementsChecker contains a class that retrieves the announcements from the unofficial Binance announcements api. It implements the exchanges.AnnouncementSource interface.
 package binanceAnnouncementsChecker
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"math/rand"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/adshao/go-binance/v2"
-	"github.com/bwmarrin/discordgo"
-	"github.com/mymmrac/telego"
-	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 	"github.com/rickstaa/crypto-listings-sniper/utils"
 	"github.com/valyala/fasthttp"
-	"golang.org/x/exp/maps"
-	"golang.org/x/time/rate"
 )
 
-// GetBinanceAnnouncementsEndpoint returns the (unofficial) binance announcements endpoint.
+// Binance announcement catalog IDs.
+const (
+	CATALOG_NEW_LISTINGS = 48
+	CATALOG_LATEST_NEWS  = 49
+	CATALOG_API_UPDATES  = 51
+	CATALOG_DELISTINGS   = 161
+)
+
+// CATALOG_NAMES contains the names of the known Binance announcement catalogs.
+// NOTE: Used when the API does not return the catalog name of an article.
+var CATALOG_NAMES = map[int64]string{
+	CATALOG_NEW_LISTINGS: "New Cryptocurrency Listing",
+	CATALOG_LATEST_NEWS:  "Latest Binance News",
+	CATALOG_API_UPDATES:  "API Updates",
+	CATALOG_DELISTINGS:   "Delisting",
+}
+
+// GetBinanceAnnouncementsEndpoint returns the (unofficial) binance announcements endpoint of a given catalog.
 // NOTE: Retrieved from https://stackoverflow.com/a/69673063/8135687.
-func GetBinanceAnnouncementsEndpoint() string {
+func GetBinanceAnnouncementsEndpoint(catalogID int64) string {
 	queries := map[string]string{
-		"catalogId": "48",
+		"catalogId": strconv.FormatInt(catalogID, 10),
 		"pageNo":    "1",
 		"pageSize":  fmt.Sprintf("%d", rand.Intn(50-10)+10),
 	}
@@ -68,122 +81,137 @@ type BinanceArticle struct {
 	CatalogId   int64  `json:"catalogId"`
 	CatalogName string `json:"catalogName"`
 	PublishDate string `json:"publishDate"`
+	ReleaseDate int64  `json:"releaseDate"`
 	Footer      string `json:"footer"`
 }
 
-// BinanceAnnouncementsChecker is a class that when started checks Binance for new announcements and posts a message in set message channels
+// publishDate returns the publish date of a Binance article.
+// NOTE: The (unofficial) API returns the date either as a millisecond 'releaseDate' timestamp or as a 'publishDate' string.
+func (article BinanceArticle) publishDate() time.Time {
+	if article.ReleaseDate > 0 {
+		return time.UnixMilli(article.ReleaseDate)
+	}
+	if publishDate, err := strconv.ParseInt(article.PublishDate, 10, 64); err == nil {
+		return time.UnixMilli(publishDate)
+	}
+	if publishDate, err := time.Parse(time.RFC3339, article.PublishDate); err == nil {
+		return publishDate
+	}
+
+	return time.Time{}
+}
+
+// BinanceAnnouncementsChecker is a class that retrieves the latest Binance announcements of a set of catalogs.
 type BinanceAnnouncementsChecker struct {
-	binanceClient               *binance.Client
-	telegramBot                 *telego.Bot
-	telegramChatID              int64
-	enableTelegramMessage       bool
-	discordBot                  *discordgo.Session
-	discordChannelIDs           []string
-	enableDiscordMessages       bool
-	lastAnnouncementWarningTime time.Time
+	binanceClient *binance.Client
+	catalogIDs    []int64
 }
 
-// newBinanceAnnouncementsChecker creates a new BinanceAnnouncementsChecker.
-func NewBinanceAnnouncementsChecker(binanceClient *binance.Client, telegramBot *telego.Bot, telegramChatID int64, enableTelegramMessage bool, discordBot *discordgo.Session, discordChannelIDs []string, enableDiscordMessages bool) *BinanceAnnouncementsChecker {
+// newBinanceAnnouncementsChecker creates a new BinanceAnnouncementsChecker for the given catalogs.
+// NOTE: The new cryptocurrency listing catalog is used when no catalogs are given.
+func NewBinanceAnnouncementsChecker(binanceClient *binance.Client, catalogIDs ...int64) *BinanceAnnouncementsChecker {
+	if len(catalogIDs) == 0 {
+		catalogIDs = []int64{CATALOG_NEW_LISTINGS}
+	}
+
 	return &BinanceAnnouncementsChecker{
-		binanceClient:               binanceClient,
-		telegramBot:                 telegramBot,
-		telegramChatID:              telegramChatID,
-		enableTelegramMessage:       enableTelegramMessage,
-		discordBot:                  discordBot,
-		discordChannelIDs:           discordChannelIDs,
-		enableDiscordMessages:       enableDiscordMessages,
-		lastAnnouncementWarningTime: time.Now(),
+		binanceClient: binanceClient,
+		catalogIDs:    catalogIDs,
 	}
 }
 
-// Retrieves the Binance announcements from the Binance announcements endpoint.
-func (blc *BinanceAnnouncementsChecker) retrieveBinanceAnnouncements() (binanceAnnouncements map[string]string) {
+// Name returns the name of the exchange.
+func (blc *BinanceAnnouncementsChecker) Name() string {
+	return "Binance"
+}
+
+// Catalogs returns the IDs of the Binance announcement catalogs that are checked.
+func (blc *BinanceAnnouncementsChecker) Catalogs() []int64 {
+	return blc.catalogIDs
+}
+
+// LegacyCatalog returns the ID of the new cryptocurrency listing catalog whose announcements were stored in 'announcements_list.json' before
+// the catalogs were checked separately.
+func (blc *BinanceAnnouncementsChecker) LegacyCatalog() int64 {
+	return CATALOG_NEW_LISTINGS
+}
+
+// RetrieveAnnouncements retrieves the Binance announcements of all catalogs concurrently.
+func (blc *BinanceAnnouncementsChecker) RetrieveAnnouncements() (binanceAnnouncements []exchanges.Announcement, err error) {
+	catalogsAnnouncements := make([][]exchanges.Announcement, len(blc.catalogIDs))
+	catalogsErrors := make([]error, len(blc.catalogIDs))
+	var wg sync.WaitGroup
+	for i, catalogID := range blc.catalogIDs {
+		wg.Add(1)
+		go func(i int, catalogID int64) {
+			defer wg.Done()
+			catalogsAnnouncements[i], catalogsErrors[i] = blc.RetrieveCatalogAnnouncements(catalogID)
+		}(i, catalogID)
+	}
+	wg.Wait()
+
+	for i, catalogAnnouncements := range catalogsAnnouncements {
+		if catalogsErrors[i] != nil {
+			return nil, catalogsErrors[i]
+		}
+		binanceAnnouncements = append(binanceAnnouncements, catalogAnnouncements...)
+	}
+	return binanceAnnouncements, nil
+}
+
+// RetrieveCatalogAnnouncements retrieves the Binance announcements of a given catalog from the Binance announcements endpoint.
+func (blc *BinanceAnnouncementsChecker) RetrieveCatalogAnnouncements(catalogID int64) (binanceAnnouncements []exchanges.Announcement, err error) {
 	request := fasthttp.AcquireRequest()
 	response := fasthttp.AcquireResponse()
 	defer fasthttp.ReleaseRequest(request)
 	defer fasthttp.ReleaseResponse(response)
 
 	// Make request.
-	request.SetRequestURI(GetBinanceAnnouncementsEndpoint())
+	request.SetRequestURI(GetBinanceAnnouncementsEndpoint(catalogID))
 	request.Header.SetMethod("GET")
 	request.Header.Set("Content-Type", "application/json")
-	err := fasthttp.Do(request, response)
+	err = fasthttp.Do(request, response)
 	if err != nil {
-		log.Fatalf("Error scraping binance announcements endpoint: %v", err)
+		return nil, fmt.Errorf("error scraping binance announcements endpoint: %w", err)
 	}
 	if response.StatusCode() != 200 {
-		if time.Since(blc.lastAnnouncementWarningTime) > time.Minute { // Only log every minute.
-			log.Printf("WARNING: Announcement API endpoint not responding.")
-			blc.lastAnnouncementWarningTime = time.Now()
-		}
-
-		return binanceAnnouncements
+		statusErr := exchanges.StatusError(response.StatusCode(), fmt.Sprintf("Binance announcements (catalog %d)", catalogID))
+		statusErr.RetryAfter = exchanges.ParseRetryAfter(string(response.Header.Peek("Retry-After")), time.Now())
+		return nil, statusErr
 	}
 
 	// Unmarshal response.
 	var announcements BinanceAnnouncements
 	err = json.Unmarshal(response.Body(), &announcements)
 	if err != nil {
-		log.Fatalf("Error unmarshalling binance announcements response: %v", err)
+		return nil, fmt.Errorf("error unmarshalling binance announcements response: %w", err)
 	}
 
 	// Return last 10 announcements.
-	binanceAnnouncements = make(map[string]string)
-	for _, article := range announcements.Data.Articles[:10] {
-		binanceAnnouncements[article.Code] = article.Title
-	}
-	return binanceAnnouncements
-}
-
-// changedListings checks whether new announcements have been published on Binance.
-func (blc *BinanceAnnouncementsChecker) binanceAnnouncementsCheck(oldAnnouncementsCodes *[]string) (newAnnouncementsCodes []string, newAnnouncements map[string]string) {
-	announcements := blc.retrieveBinanceAnnouncements()
-	if len(announcements) == 0 {
-		return nil, nil
-	}
-
-	// Check if new announcements have been published.
-	announcementsCodes := maps.Keys(announcements)
-	_, newAnnouncementsCodes = utils.CompareLists(*oldAnnouncementsCodes, announcementsCodes)
-	*oldAnnouncementsCodes = announcementsCodes
-
-	// Create new announcements map.
-	newAnnouncements = make(map[string]string)
-	for _, code := range newAnnouncementsCodes {
-		newAnnouncements[code] = announcements[code]
-	}
-
-	return newAnnouncementsCodes, newAnnouncements
-}
-
-// Start starts the BinanceAnnouncementsChecker.
-func (blc *BinanceAnnouncementsChecker) Start(maxRate float64) {
-	// Retrieve (old) Binance announcements.
-	oldAnnouncements := utils.RetrieveOldAnnouncements()
-	if len(oldAnnouncements) == 0 { // Get from Binance if no old announcements are stored.
-		binanceAnnouncements := blc.retrieveBinanceAnnouncements()
-		oldAnnouncements = maps.Keys(binanceAnnouncements)
-		utils.StoreOldAnnouncements(oldAnnouncements)
+	articles := announcements.Data.Articles
+	if len(articles) > 10 {
+		articles = articles[:10]
 	}
-
-	// Check binance for new announcements and post Telegram/Discord message.
-	limiter := rate.NewLimiter(rate.Limit(maxRate), 1)
-	for {
-		limiter.Wait(context.Background()) // NOTE: This is to prevent binance from blocking the IP address.
-
-		// Check for new announcements.
-		newAnnouncementsCodes, newAnnouncements := blc.binanceAnnouncementsCheck(&oldAnnouncements)
-
-		// Post messages.
-		for _, announcementCode := range newAnnouncementsCodes {
-			// Log announcement.
-			log.Printf("New Binance announcement: %s", newAnnouncements[announcementCode])
-
-			// Post telegram and discord messages.
-			go messaging.SendAnnouncementMessage(blc.telegramBot, blc.telegramChatID, blc.enableTelegramMessage, blc.discordBot, blc.discordChannelIDs, blc.enableDiscordMessages, announcementCode, newAnnouncements[announcementCode])
-
-			utils.StoreOldAnnouncements(oldAnnouncements)
+	for _, article := range articles {
+		kind, tickers, pairs, dates := ParseAnnouncementTitle(article.Title)
+		catalogName := article.CatalogName
+		if catalogName == "" {
+			catalogName = CATALOG_NAMES[catalogID]
 		}
+		binanceAnnouncements = append(binanceAnnouncements, exchanges.Announcement{
+			Exchange:    blc.Name(),
+			ID:          article.ID,
+			Code:        article.Code,
+			Title:       article.Title,
+			URL:         utils.CreateBinanceArticleURL(article.Code, article.Title),
+			CatalogID:   catalogID,
+			CatalogName: catalogName,
+			PublishDate: article.publishDate(),
+			Kind:        kind,
+			Tickers:     tickers,
+			Pairs:       pairs,
+			Dates:       dates,
+		})
 	}
+	return binanceAnnouncements, nil
 }
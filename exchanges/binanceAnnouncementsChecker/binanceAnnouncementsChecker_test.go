@@ -0,0 +1,64 @@
+// Description: Tests for the binanceAnnouncementsChecker package.
+
+package binanceAnnouncementsChecker
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPublishDate tests the publishDate function.
+func TestPublishDate(t *testing.T) {
+	expected := time.UnixMilli(1684137600000)
+	if r := (BinanceArticle{ReleaseDate: 1684137600000}).publishDate(); !r.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, r)
+	}
+	if r := (BinanceArticle{PublishDate: "1684137600000"}).publishDate(); !r.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, r)
+	}
+	if r := (BinanceArticle{PublishDate: "2023-05-15T08:00:00Z"}).publishDate(); !r.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, r)
+	}
+	if r := (BinanceArticle{}).publishDate(); !r.IsZero() {
+		t.Errorf("Expected zero time, got %v", r)
+	}
+}
+
+// TestParseAnnouncementTitle tests the ParseAnnouncementTitle function.
+func TestParseAnnouncementTitle(t *testing.T) {
+	tests := []struct {
+		title   string
+		kind    string
+		tickers []string
+		pairs   []string
+		dates   []string
+	}{
+		{"Binance Will List Foo (FOO) and Bar (BAR)", "listing", []string{"FOO", "BAR"}, nil, nil},
+		{"Binance Will List Foo (FOO) with Seed Tag Applied (FOO/USDT, FOO/BTC)", "listing", []string{"FOO"}, []string{"FOO/USDT", "FOO/BTC"}, nil},
+		{"Binance Will Delist FOO, BAR & BAZ on 2023-05-15", "delisting", []string{"FOO", "BAR", "BAZ"}, nil, []string{"2023-05-15"}},
+		{"Notice of Removal of Spot Trading Pairs - 2023-05-12", "delisting", nil, nil, []string{"2023-05-12"}},
+		{"Binance Futures Will Launch USDⓈ-M FOOUSDT Perpetual Contract With Up to 20x Leverage", "futures", nil, []string{"FOOUSDT"}, nil},
+		{"Introducing Foo (FOO) on Binance Launchpool! Farm FOO by Staking BNB and TUSD", "launchpool", []string{"FOO"}, nil, nil},
+		{"Binance Will Perform Scheduled System Maintenance (2023-05-15 02:00 UTC)", "maintenance", nil, nil, []string{"2023-05-15 02:00 UTC"}},
+		{"Binance Completes the Foo Network Upgrade", "maintenance", nil, nil, nil},
+		{"Binance Will Support the Foo (FOO) Network Upgrade 2023-05-15 08:00 (UTC)", "maintenance", []string{"FOO"}, nil, []string{"2023-05-15 08:00 (UTC)"}},
+		{"Binance Margin Will Add New Pairs on Cross Margin & Isolated Margin (FOO/USDT)", "margin", nil, []string{"FOO/USDT"}, nil},
+		{"Binance Celebrates Its Anniversary", "other", nil, nil, nil},
+	}
+	for _, test := range tests {
+		kind, tickers, pairs, dates := ParseAnnouncementTitle(test.title)
+		if kind != test.kind {
+			t.Errorf("%s: expected kind %s, got %s", test.title, test.kind, kind)
+		}
+		if strings.Join(tickers, ",") != strings.Join(test.tickers, ",") {
+			t.Errorf("%s: expected tickers %v, got %v", test.title, test.tickers, tickers)
+		}
+		if strings.Join(pairs, ",") != strings.Join(test.pairs, ",") {
+			t.Errorf("%s: expected pairs %v, got %v", test.title, test.pairs, pairs)
+		}
+		if strings.Join(dates, ",") != strings.Join(test.dates, ",") {
+			t.Errorf("%s: expected dates %v, got %v", test.title, test.dates, dates)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Description: Contains a parser that extracts structured information from Binance announcement titles.
+package binanceAnnouncementsChecker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// Announcement title regular expressions.
+var (
+	TICKER_REGEX        = regexp.MustCompile(`\(([A-Z0-9]{2,15})\)`)
+	DELIST_REGEX        = regexp.MustCompile(`(?i)\bdelist\s+(.+?)(?:\s+on\s+|\s*$)`)
+	DELIST_TICKER_REGEX = regexp.MustCompile(`^[A-Z0-9]{2,15}$`)
+	PAIR_REGEX          = regexp.MustCompile(`\b([A-Z0-9]{2,15}/[A-Z0-9]{2,15})\b`)
+	PERPETUAL_REGEX     = regexp.MustCompile(`\b([A-Z0-9]{2,15}(?:USDT|USDC|BUSD|USD))\s+Perpetual`)
+	DATE_REGEX          = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2}(?:\s+\d{2}:\d{2}(?:\s+UTC|\s*\(UTC\))?)?)`)
+)
+
+// NON_TICKERS contains uppercase words that look like tickers but are not.
+var NON_TICKERS = map[string]bool{"UTC": true, "API": true, "ON": true, "AND": true}
+
+// ANNOUNCEMENT_KIND_KEYWORDS contains the (lowercase) title keywords that are used to determine the announcement kind.
+// NOTE: The kinds are checked in order.
+var ANNOUNCEMENT_KIND_KEYWORDS = []struct {
+	Kind     string
+	Keywords []string
+}{
+	{exchanges.ANNOUNCEMENT_DELISTING, []string{"delist", "removal of", "will remove", "cease trading"}},
+	{exchanges.ANNOUNCEMENT_LAUNCHPOOL, []string{"launchpool", "launchpad", "megadrop"}},
+	{exchanges.ANNOUNCEMENT_FUTURES, []string{"futures will launch", "perpetual contract", "delivery contract", "binance futures"}},
+	{exchanges.ANNOUNCEMENT_MARGIN, []string{"binance margin", "isolated margin", "cross margin", "borrowable"}},
+	{exchanges.ANNOUNCEMENT_MAINTENANCE, []string{"maintenance", "upgrade", "suspend deposits", "suspension of deposits"}},
+	{exchanges.ANNOUNCEMENT_LISTING, []string{"will list", "lists", "new listing", "will add", "adds"}},
+}
+
+// appendUnique appends a string to a slice of strings if it is not already present.
+func appendUnique(s []string, str string) []string {
+	for _, v := range s {
+		if v == str {
+			return s
+		}
+	}
+
+	return append(s, str)
+}
+
+// announcementKind returns the kind of a announcement based on its title.
+func announcementKind(title string) string {
+	lowerTitle := strings.ToLower(title)
+	for _, kindKeywords := range ANNOUNCEMENT_KIND_KEYWORDS {
+		for _, keyword := range kindKeywords.Keywords {
+			if strings.Contains(lowerTitle, keyword) {
+				return kindKeywords.Kind
+			}
+		}
+	}
+
+	return exchanges.ANNOUNCEMENT_OTHER
+}
+
+// ParseAnnouncementTitle extracts the kind, ticker symbols, trading pairs and dates from a Binance announcement title.
+func ParseAnnouncementTitle(title string) (kind string, tickers []string, pairs []string, dates []string) {
+	kind = announcementKind(title)
+
+	// Extract tickers (e.g. 'Binance Will List Foo (FOO)').
+	for _, match := range TICKER_REGEX.FindAllStringSubmatch(title, -1) {
+		if !NON_TICKERS[match[1]] {
+			tickers = appendUnique(tickers, match[1])
+		}
+	}
+
+	// Extract delisted tickers (e.g. 'Binance Will Delist FOO, BAR & BAZ on 2023-05-15').
+	if kind == exchanges.ANNOUNCEMENT_DELISTING && len(tickers) == 0 {
+		if match := DELIST_REGEX.FindStringSubmatch(title); match != nil {
+			for _, word := range strings.FieldsFunc(match[1], func(r rune) bool {
+				return r == ',' || r == '&' || r == ' '
+			}) {
+				if DELIST_TICKER_REGEX.MatchString(word) && !NON_TICKERS[word] {
+					tickers = appendUnique(tickers, word)
+				}
+			}
+		}
+	}
+
+	// Extract trading pairs (e.g. 'FOO/USDT' or 'FOOUSDT Perpetual').
+	for _, match := range PAIR_REGEX.FindAllStringSubmatch(title, -1) {
+		pairs = appendUnique(pairs, match[1])
+	}
+	for _, match := range PERPETUAL_REGEX.FindAllStringSubmatch(title, -1) {
+		pairs = appendUnique(pairs, match[1])
+	}
+
+	// Extract dates (e.g. '2023-05-15' or '2023-05-15 08:00 (UTC)').
+	for _, match := range DATE_REGEX.FindAllStringSubmatch(title, -1) {
+		dates = appendUnique(dates, strings.TrimSpace(match[1]))
+	}
+
+	return kind, tickers, pairs, dates
+}
@@ -0,0 +1,165 @@
+// Description: Package binanceFuturesListingsChecker contains a class that retrieves the Binance USDⓈ-M and COIN-M futures contracts. It implements the exchanges.ListingSource interface.
+package binanceFuturesListingsChecker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/delivery"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/binanceListingsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+)
+
+// Binance futures markets.
+const (
+	MARKET_USDM  = "usdm"
+	MARKET_COINM = "coinm"
+)
+
+// FUTURES_WEIGHT_LIMIT is the request weight limit per minute of the Binance futures APIs.
+const FUTURES_WEIGHT_LIMIT = 2400
+
+// BinanceFuturesListingsChecker is a class that retrieves the contracts of a Binance futures market.
+type BinanceFuturesListingsChecker struct {
+	name              string
+	EndpointPool      *binanceListingsChecker.EndpointPool
+	retrieveContracts func() ([]exchanges.SymbolInfo, error)
+	contracts         map[string]exchanges.SymbolInfo
+	mu                sync.RWMutex
+}
+
+// newBinanceFuturesListingsChecker creates a new BinanceFuturesListingsChecker that sends the requests of a HTTP client through a endpoint pool.
+func newBinanceFuturesListingsChecker(name string, baseURL string, httpClient **http.Client) (*BinanceFuturesListingsChecker, error) {
+	endpointPool, err := binanceListingsChecker.NewEndpointPool(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s endpoint pool: %w", name, err)
+	}
+	endpointPool.SetWeightBudget(FUTURES_WEIGHT_LIMIT, binanceListingsChecker.DEFAULT_WEIGHT_BUDGET)
+	client := **httpClient
+	client.Transport = endpointPool
+	*httpClient = &client
+
+	return &BinanceFuturesListingsChecker{
+		name:         name,
+		EndpointPool: endpointPool,
+		contracts:    make(map[string]exchanges.SymbolInfo),
+	}, nil
+}
+
+// NewBinanceUSDMListingsChecker creates a new BinanceFuturesListingsChecker for the USDⓈ-M futures market.
+func NewBinanceUSDMListingsChecker(futuresClient *futures.Client) (*BinanceFuturesListingsChecker, error) {
+	bflc, err := newBinanceFuturesListingsChecker("Binance USD-M Futures", futuresClient.BaseURL, &futuresClient.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	bflc.retrieveContracts = func() ([]exchanges.SymbolInfo, error) {
+		exchangeInfo, err := futuresClient.NewExchangeInfoService().Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		contracts := make([]exchanges.SymbolInfo, len(exchangeInfo.Symbols))
+		for i, symbol := range exchangeInfo.Symbols {
+			contracts[i] = exchanges.SymbolInfo{
+				Exchange:     bflc.name,
+				Symbol:       symbol.Symbol,
+				BaseAsset:    symbol.BaseAsset,
+				QuoteAsset:   symbol.QuoteAsset,
+				Status:       symbol.Status,
+				URL:          utils.CreateBinanceFuturesURL(symbol.Symbol),
+				ContractType: string(symbol.ContractType),
+				OnboardDate:  time.UnixMilli(symbol.OnboardDate).UTC(),
+			}
+		}
+		return contracts, nil
+	}
+	return bflc, nil
+}
+
+// NewBinanceCOINMListingsChecker creates a new BinanceFuturesListingsChecker for the COIN-M futures market.
+func NewBinanceCOINMListingsChecker(deliveryClient *delivery.Client) (*BinanceFuturesListingsChecker, error) {
+	bflc, err := newBinanceFuturesListingsChecker("Binance COIN-M Futures", deliveryClient.BaseURL, &deliveryClient.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	bflc.retrieveContracts = func() ([]exchanges.SymbolInfo, error) {
+		exchangeInfo, err := deliveryClient.NewExchangeInfoService().Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		contracts := make([]exchanges.SymbolInfo, len(exchangeInfo.Symbols))
+		for i, symbol := range exchangeInfo.Symbols {
+			contracts[i] = exchanges.SymbolInfo{
+				Exchange:     bflc.name,
+				Symbol:       symbol.Symbol,
+				BaseAsset:    symbol.BaseAsset,
+				QuoteAsset:   symbol.QuoteAsset,
+				Status:       symbol.ContractStatus,
+				URL:          utils.CreateBinanceDeliveryURL(symbol.Symbol),
+				ContractType: symbol.ContractType,
+				OnboardDate:  time.UnixMilli(symbol.OnboardDate).UTC(),
+			}
+		}
+		return contracts, nil
+	}
+	return bflc, nil
+}
+
+// NewBinanceFuturesListingsChecker creates a new BinanceFuturesListingsChecker for a given futures market.
+func NewBinanceFuturesListingsChecker(market string, apiKey string, secretKey string) (*BinanceFuturesListingsChecker, error) {
+	switch market {
+	case MARKET_USDM:
+		return NewBinanceUSDMListingsChecker(futures.NewClient(apiKey, secretKey))
+	case MARKET_COINM:
+		return NewBinanceCOINMListingsChecker(delivery.NewClient(apiKey, secretKey))
+	default:
+		return nil, fmt.Errorf("unknown Binance futures market '%s'", market)
+	}
+}
+
+// Name returns the name of the futures market.
+func (bflc *BinanceFuturesListingsChecker) Name() string {
+	return bflc.name
+}
+
+// RetrieveSymbols retrieves a list with the contract symbols of the futures market.
+func (bflc *BinanceFuturesListingsChecker) RetrieveSymbols() (symbols []string, err error) {
+	contracts, err := bflc.retrieveContracts()
+	if err != nil {
+		return nil, bflc.EndpointPool.RequestError(err)
+	}
+
+	// Cache contracts.
+	symbols = make([]string, len(contracts))
+	bflc.mu.Lock()
+	defer bflc.mu.Unlock()
+	for i, contract := range contracts {
+		symbols[i] = contract.Symbol
+		bflc.contracts[contract.Symbol] = contract
+	}
+	return symbols, nil
+}
+
+// RetrieveSymbolInfo retrieves the exchange agnostic information about a given contract.
+// NOTE: Uses the information retrieved by the last RetrieveSymbols call.
+func (bflc *BinanceFuturesListingsChecker) RetrieveSymbolInfo(symbol string) (exchanges.SymbolInfo, error) {
+	bflc.mu.RLock()
+	defer bflc.mu.RUnlock()
+	contract, ok := bflc.contracts[symbol]
+	if !ok {
+		return exchanges.SymbolInfo{}, fmt.Errorf("contract '%s' not found on %s", symbol, bflc.name)
+	}
+
+	return contract, nil
+}
+
+// AdaptRate returns the request rate that keeps the Binance futures request weight usage within budget.
+func (bflc *BinanceFuturesListingsChecker) AdaptRate(maxRate float64) float64 {
+	return bflc.EndpointPool.AdaptRate(maxRate)
+}
@@ -0,0 +1,99 @@
+// Description: Tests for the binanceFuturesListingsChecker package.
+
+package binanceFuturesListingsChecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/delivery"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// TestUSDMListings tests that the USDⓈ-M futures contracts and their contract type and onboard date are retrieved.
+func TestUSDMListings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/exchangeInfo" {
+			t.Errorf("Expected %s, got %s", "/fapi/v1/exchangeInfo", r.URL.Path)
+		}
+		w.Write([]byte(`{"symbols":[{"symbol":"FOOUSDT","pair":"FOOUSDT","contractType":"PERPETUAL","onboardDate":1709280000000,"status":"PENDING_TRADING","baseAsset":"FOO","quoteAsset":"USDT"}]}`))
+	}))
+	defer server.Close()
+	client := futures.NewClient("", "")
+	client.BaseURL = server.URL
+	bflc, err := NewBinanceUSDMListingsChecker(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	symbols, err := bflc.RetrieveSymbols()
+	if err != nil || len(symbols) != 1 || symbols[0] != "FOOUSDT" {
+		t.Errorf("Expected %v, got %v (%v)", []string{"FOOUSDT"}, symbols, err)
+	}
+	contract, err := bflc.RetrieveSymbolInfo("FOOUSDT")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if !contract.IsFuturesContract() || contract.ContractType != "PERPETUAL" {
+		t.Errorf("Expected %s, got %s", "PERPETUAL", contract.ContractType)
+	}
+	if expected := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC); !contract.OnboardDate.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, contract.OnboardDate)
+	}
+	if contract.Exchange != "Binance USD-M Futures" || contract.Status != "PENDING_TRADING" {
+		t.Errorf("Expected %s, got %s", "Binance USD-M Futures (PENDING_TRADING)", contract.Exchange+" ("+contract.Status+")")
+	}
+	if _, err := bflc.RetrieveSymbolInfo("BARUSDT"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestCOINMListings tests that the COIN-M futures contracts are retrieved with their contract status.
+func TestCOINMListings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dapi/v1/exchangeInfo" {
+			t.Errorf("Expected %s, got %s", "/dapi/v1/exchangeInfo", r.URL.Path)
+		}
+		w.Write([]byte(`{"symbols":[{"symbol":"FOOUSD_240628","pair":"FOOUSD","contractType":"CURRENT_QUARTER","onboardDate":1709280000000,"contractStatus":"TRADING","baseAsset":"FOO","quoteAsset":"USD"}]}`))
+	}))
+	defer server.Close()
+	client := delivery.NewClient("", "")
+	client.BaseURL = server.URL
+	bflc, err := NewBinanceCOINMListingsChecker(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := bflc.RetrieveSymbols(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	contract, _ := bflc.RetrieveSymbolInfo("FOOUSD_240628")
+	if contract.ContractType != "CURRENT_QUARTER" || contract.Status != "TRADING" {
+		t.Errorf("Expected %s, got %s", "CURRENT_QUARTER (TRADING)", contract.ContractType+" ("+contract.Status+")")
+	}
+	if contract.URL != "https://www.binance.com/en/delivery/foousd_240628" {
+		t.Errorf("Expected %s, got %s", "https://www.binance.com/en/delivery/foousd_240628", contract.URL)
+	}
+}
+
+// TestNewBinanceFuturesListingsChecker tests that unknown futures markets are rejected.
+func TestNewBinanceFuturesListingsChecker(t *testing.T) {
+	if _, err := NewBinanceFuturesListingsChecker("options", "", ""); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	bflc, err := NewBinanceFuturesListingsChecker(MARKET_COINM, "", "")
+	if err != nil || bflc.Name() != "Binance COIN-M Futures" {
+		t.Errorf("Expected %s, got %v (%v)", "Binance COIN-M Futures", bflc, err)
+	}
+}
+
+// TestInvalidEndpoint tests that a invalid futures client endpoint is returned as a error instead of installing a unusable endpoint pool.
+func TestInvalidEndpoint(t *testing.T) {
+	client := futures.NewClient("", "")
+	client.BaseURL = "fapi.binance.com"
+	if bflc, err := NewBinanceUSDMListingsChecker(client); err == nil || bflc != nil {
+		t.Errorf("Expected error, got %v", bflc)
+	}
+}
@@ -0,0 +1,302 @@
+package binanceListingsChecker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// Binance endpoint pool settings.
+const (
+	MAX_ROUTE_ATTEMPTS    = 3               // Number of routes that are tried before a request fails.
+	MAX_ROUTE_LATENCY     = 2 * time.Second // Routes that respond slower are marked unhealthy.
+	ROUTE_TIMEOUT         = 10 * time.Second
+	MIN_UNHEALTHY_BACKOFF = 1 * time.Second
+	MAX_UNHEALTHY_BACKOFF = 5 * time.Minute
+)
+
+// NewSourceTransport returns a HTTP transport that sends the requests from a given local IP address or through a given proxy URL (e.g. 'socks5://127.0.0.1:1080').
+// NOTE: The default transport is returned if the source is empty.
+func NewSourceTransport(source string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = ROUTE_TIMEOUT
+	switch {
+	case source == "":
+		return transport, nil
+	case strings.Contains(source, "://"):
+		proxyURL, err := url.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL '%s': %w", source, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		return transport, nil
+	default:
+		ip := net.ParseIP(source)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid source address '%s'", source)
+		}
+		dialer := &net.Dialer{LocalAddr: &net.TCPAddr{IP: ip}, Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+		return transport, nil
+	}
+}
+
+// route represents a Binance API endpoint that is reached from a source address.
+type route struct {
+	endpoint       *url.URL
+	source         *WeightTracker
+	failures       int
+	unhealthyUntil time.Time
+	latency        time.Duration // Moving average of the response time.
+}
+
+func (r *route) String() string {
+	return fmt.Sprintf("%s via %s", r.endpoint.Host, r.source.name)
+}
+
+// EndpointPool is a class that spreads the Binance requests round-robin over multiple API endpoints and source addresses and skips the unhealthy ones. It implements the http.RoundTripper interface.
+// NOTE: The request weight is tracked per source address since Binance limits the request weight per IP address.
+type EndpointPool struct {
+	routes       []*route
+	sources      []*WeightTracker
+	weightHeader string
+	weightLimit  int
+	weightBudget float64
+	next         int
+	mutex        sync.Mutex
+}
+
+// NewEndpointPool creates a new EndpointPool for the given API endpoints that sends the requests from the default source address.
+func NewEndpointPool(endpoints ...string) (*EndpointPool, error) {
+	ep := &EndpointPool{
+		weightHeader: USED_WEIGHT_HEADER,
+		weightLimit:  DEFAULT_WEIGHT_LIMIT,
+		weightBudget: DEFAULT_WEIGHT_BUDGET,
+	}
+	if err := ep.SetEndpoints(endpoints, nil); err != nil {
+		return nil, err
+	}
+	return ep, nil
+}
+
+// SetEndpoints sets the API endpoints and the source addresses or proxy URLs from which they are reached.
+// NOTE: The default source address is used if no sources are given.
+func (ep *EndpointPool) SetEndpoints(endpoints []string, sources []string) error {
+	if len(endpoints) == 0 {
+		return errors.New("no Binance API endpoints given")
+	}
+	if len(sources) == 0 {
+		sources = []string{""}
+	}
+
+	// Create a weight tracker per source.
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	var weightTrackers []*WeightTracker
+	for _, source := range sources {
+		transport, err := NewSourceTransport(source)
+		if err != nil {
+			return err
+		}
+		name := source
+		if name == "" {
+			name = "default"
+		}
+		weightTracker := NewWeightTracker(name, transport)
+		weightTracker.SetWeightHeader(ep.weightHeader)
+		weightTracker.SetWeightBudget(ep.weightLimit, ep.weightBudget)
+		weightTrackers = append(weightTrackers, weightTracker)
+	}
+
+	// Create routes.
+	// NOTE: The sources are interleaved so that consecutive requests are sent from different sources.
+	var routes []*route
+	for _, endpoint := range endpoints {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+			return fmt.Errorf("invalid Binance API endpoint '%s'", endpoint)
+		}
+		for _, weightTracker := range weightTrackers {
+			routes = append(routes, &route{endpoint: endpointURL, source: weightTracker})
+		}
+	}
+
+	ep.routes = routes
+	ep.sources = weightTrackers
+	ep.next = 0
+	return nil
+}
+
+// SetWeightBudget sets the request weight limit and the fraction of it that may be used per source.
+func (ep *EndpointPool) SetWeightBudget(weightLimit int, weightBudget float64) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.weightLimit = weightLimit
+	ep.weightBudget = weightBudget
+	for _, source := range ep.sources {
+		source.SetWeightBudget(weightLimit, weightBudget)
+	}
+}
+
+// SetWeightHeader sets the response header that contains the used request weight of the sources.
+func (ep *EndpointPool) SetWeightHeader(weightHeader string) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.weightHeader = weightHeader
+	for _, source := range ep.sources {
+		source.SetWeightHeader(weightHeader)
+	}
+}
+
+// RetryAfter returns the time that Binance asked to wait before the next request can be sent from any of the sources.
+func (ep *EndpointPool) RetryAfter() (retryAfter time.Duration) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	for i, source := range ep.sources {
+		sourceRetryAfter := source.RetryAfter()
+		if i == 0 || sourceRetryAfter < retryAfter {
+			retryAfter = sourceRetryAfter
+		}
+	}
+	return retryAfter
+}
+
+// RequestError classifies the error of a Binance request and adds the wait time of the 'Retry-After' header.
+func (ep *EndpointPool) RequestError(err error) error {
+	err = ClassifyError(err)
+	var exchangeErr *exchanges.Error
+	if errors.As(err, &exchangeErr) && exchangeErr.RetryAfter <= 0 {
+		exchangeErr.RetryAfter = ep.RetryAfter()
+	}
+	return err
+}
+
+// AdaptRate returns the request rate that keeps the request weight usage of all sources within budget.
+// NOTE: Paused sources are skipped since the requests are sent from the other sources.
+func (ep *EndpointPool) AdaptRate(maxRate float64) float64 {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	adaptedRate := 0.0
+	for _, source := range ep.sources {
+		if source.RetryAfter() <= 0 {
+			adaptedRate += source.AdaptRate(maxRate)
+		}
+	}
+	if adaptedRate == 0 || adaptedRate > maxRate {
+		return maxRate
+	}
+	return adaptedRate
+}
+
+// pick returns the next healthy route that was not tried yet.
+// NOTE: The route that becomes healthy first is returned if all routes are unhealthy.
+func (ep *EndpointPool) pick(tried map[*route]bool, now time.Time) *route {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	var fallback *route
+	for i := 0; i < len(ep.routes); i++ {
+		r := ep.routes[(ep.next+i)%len(ep.routes)]
+		if tried[r] {
+			continue
+		}
+		if now.After(r.unhealthyUntil) && r.source.RetryAfter() <= 0 {
+			ep.next = (ep.next + i + 1) % len(ep.routes)
+			return r
+		}
+		if fallback == nil || r.unhealthyUntil.Before(fallback.unhealthyUntil) {
+			fallback = r
+		}
+	}
+	return fallback
+}
+
+// markUnhealthy marks a route as unhealthy with exponential backoff.
+func (ep *EndpointPool) markUnhealthy(r *route, reason string) {
+	r.failures++
+	backoff := MIN_UNHEALTHY_BACKOFF
+	for i := 1; i < r.failures && backoff < MAX_UNHEALTHY_BACKOFF; i++ {
+		backoff *= 2
+	}
+	if backoff > MAX_UNHEALTHY_BACKOFF {
+		backoff = MAX_UNHEALTHY_BACKOFF
+	}
+	r.unhealthyUntil = time.Now().Add(backoff)
+	log.Printf("WARNING: Binance endpoint %s unhealthy for %v: %s", r, backoff, reason)
+}
+
+// update updates the health and latency of a route and returns whether the request should be retried on another route.
+func (ep *EndpointPool) update(r *route, response *http.Response, err error, latency time.Duration) (retry bool) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	var exchangeErr *exchanges.Error
+	switch {
+	case errors.As(err, &exchangeErr): // NOTE: The source is paused so the request was not sent.
+		return true
+	case err != nil:
+		ep.markUnhealthy(r, err.Error())
+		return true
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusTeapot: // NOTE: The source is paused by its weight tracker.
+		return true
+	case response.StatusCode >= 500:
+		ep.markUnhealthy(r, fmt.Sprintf("status code %d", response.StatusCode))
+		return true
+	}
+
+	// Update latency.
+	if r.latency == 0 {
+		r.latency = latency
+	} else {
+		r.latency = (4*r.latency + latency) / 5
+	}
+	if r.latency > MAX_ROUTE_LATENCY {
+		ep.markUnhealthy(r, fmt.Sprintf("slow responses (%v)", r.latency.Round(time.Millisecond)))
+		r.latency = 0 // NOTE: Measured again when the route becomes healthy.
+		return false
+	}
+	if r.failures != 0 {
+		log.Printf("Binance endpoint %s healthy again (latency %v).", r, r.latency.Round(time.Millisecond))
+		r.failures = 0
+	}
+	return false
+}
+
+// RoundTrip sends a request to the next healthy route and retries it on another route if it failed.
+// NOTE: Only GET requests are retried so that orders are never sent twice.
+func (ep *EndpointPool) RoundTrip(request *http.Request) (response *http.Response, err error) {
+	tried := make(map[*route]bool)
+	for attempt := 0; attempt < MAX_ROUTE_ATTEMPTS; attempt++ {
+		r := ep.pick(tried, time.Now())
+		if r == nil {
+			break
+		}
+		tried[r] = true
+		if response != nil {
+			response.Body.Close()
+		}
+
+		// Send the request to the route.
+		routeRequest := request.Clone(request.Context())
+		routeRequest.URL.Scheme = r.endpoint.Scheme
+		routeRequest.URL.Host = r.endpoint.Host
+		routeRequest.Host = ""
+		tStart := time.Now()
+		response, err = r.source.RoundTrip(routeRequest)
+		retry := ep.update(r, response, err, time.Since(tStart))
+		if !retry || request.Method != http.MethodGet {
+			break
+		}
+	}
+	if response == nil && err == nil {
+		err = errors.New("no Binance API endpoints available")
+	}
+	return response, err
+}
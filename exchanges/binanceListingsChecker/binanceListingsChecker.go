@@ -1,159 +1,190 @@
-// Description: Package binanceListingsChecker contains a class that when started periodically checks Binance for new listings or de-listings and posts a message in set message channels.
+// Description: Package binanceListingsChecker contains a class that retrieves the Binance listings. It implements the exchanges.ListingSource interface.
 package binanceListingsChecker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/adshao/go-binance/v2"
 	"github.com/adshao/go-binance/v2/common"
-	"github.com/bwmarrin/discordgo"
-	"github.com/mymmrac/telego"
-	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 	"github.com/rickstaa/crypto-listings-sniper/utils"
 	"golang.org/x/time/rate"
 )
 
-// BinanceListingsChecker is a class that when started checks Binance for new listings or de-listings and posts a message in set message channels
+// BANNED_UNTIL_REGEX matches the ban expiry timestamp (in milliseconds) in the Binance '-1003' error message.
+var BANNED_UNTIL_REGEX = regexp.MustCompile(`banned until (\d+)`)
+
+// ClassifyError converts a Binance API error into a classified exchange error.
+// NOTE: Binance returns error '-1003' when the request weight limit is exceeded and mentions the ban expiry in the message when the IP address is banned.
+func ClassifyError(err error) error {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return err // NOTE: Network errors are treated as transient.
+	}
+
+	switch {
+	case apiErr.Code == -1003 && strings.Contains(strings.ToLower(apiErr.Message), "banned"):
+		exchangeErr := exchanges.NewError(exchanges.ERROR_BANNED, err)
+		if match := BANNED_UNTIL_REGEX.FindStringSubmatch(apiErr.Message); match != nil {
+			bannedUntil, _ := strconv.ParseInt(match[1], 10, 64)
+			exchangeErr.RetryAfter = time.Until(time.UnixMilli(bannedUntil))
+		}
+		return exchangeErr
+	case apiErr.Code == -1003 || apiErr.Code == -1015:
+		return exchanges.NewError(exchanges.ERROR_RATE_LIMITED, err)
+	case apiErr.Code == 0 || (apiErr.Code <= -1000 && apiErr.Code > -1100): // NOTE: Unparsable responses and '-10xx' server or network errors.
+		return exchanges.NewError(exchanges.ERROR_TRANSIENT, err)
+	default:
+		return exchanges.NewError(exchanges.ERROR_FATAL, err)
+	}
+}
+
+// BinanceListingsChecker is a class that retrieves the Binance listings and symbol information.
 type BinanceListingsChecker struct {
 	BinanceClient             *binance.Client
-	TelegramBot               *telego.Bot
-	TelegramChatID            int64
-	EnableTelegramMessage     bool
-	DiscordBot                *discordgo.Session
-	DiscordChannelIDs         []string
-	EnableDiscordMessages     bool
-	OldAssets                 *[]string
+	EndpointPool              *EndpointPool
 	maxRate                   float64
-	lastAssetsWarningTime     time.Time
 	lastSymbolInfoWarningTime time.Time
 }
 
 // NewBinanceListingsChecker creates a new BinanceListingsChecker.
-func NewBinanceListingsChecker(binanceClient *binance.Client, telegramBot *telego.Bot, telegramChatID int64, enableTelegramMessage bool, discordBot *discordgo.Session, discordChannelIDs []string, enableDiscordMessages bool) *BinanceListingsChecker {
+// NOTE: The HTTP client of the Binance client is replaced with a client that sends the requests through a endpoint pool. The pool only contains the
+// Binance client endpoint until other endpoints are set.
+func NewBinanceListingsChecker(binanceClient *binance.Client, maxRate float64) (*BinanceListingsChecker, error) {
+	endpointPool, err := NewEndpointPool(binanceClient.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Binance endpoint pool: %w", err)
+	}
+	httpClient := *binanceClient.HTTPClient
+	httpClient.Transport = endpointPool
+	binanceClient.HTTPClient = &httpClient
+
 	return &BinanceListingsChecker{
 		BinanceClient:             binanceClient,
-		TelegramBot:               telegramBot,
-		TelegramChatID:            telegramChatID,
-		EnableTelegramMessage:     enableTelegramMessage,
-		DiscordBot:                discordBot,
-		DiscordChannelIDs:         discordChannelIDs,
-		EnableDiscordMessages:     enableDiscordMessages,
-		lastAssetsWarningTime:     time.Now(),
+		EndpointPool:              endpointPool,
+		maxRate:                   maxRate,
 		lastSymbolInfoWarningTime: time.Now(),
-	}
+	}, nil
+}
+
+// AdaptRate returns the request rate that keeps the Binance request weight usage within budget.
+func (blc *BinanceListingsChecker) AdaptRate(maxRate float64) float64 {
+	return blc.EndpointPool.AdaptRate(maxRate)
 }
 
-// retrieveBinanceAssets retrieves a list with the available assets from Binance.
-// NOTE: Retry if failed and throw warning every minute.
-func (blc *BinanceListingsChecker) retrieveBinanceAssets() (assets []string) {
+// Name returns the name of the exchange.
+func (blc *BinanceListingsChecker) Name() string {
+	return "Binance"
+}
+
+// RetrieveSymbols retrieves a list with the available assets from Binance.
+func (blc *BinanceListingsChecker) RetrieveSymbols() (assets []string, err error) {
 	// Retrieve listing prices from Binance.
 	priceService := blc.BinanceClient.NewListPricesService()
 	listingPrices, err := priceService.Do(context.Background())
-	assets = make([]string, len(listingPrices))
-
-	// Log warning if failed.
 	if err != nil {
-		if time.Since(blc.lastAssetsWarningTime) > time.Minute { // Only log every minute.
-			log.Printf("WARNING: Error retrieving Binance listing prices: %v", err)
-			blc.lastAssetsWarningTime = time.Now()
-		}
+		return nil, blc.EndpointPool.RequestError(err)
 	}
 
 	// Return assets.
+	assets = make([]string, len(listingPrices))
 	for i, s := range listingPrices {
 		assets[i] = s.Symbol
 	}
-	return assets
+	return assets, nil
+}
+
+// RetrieveSymbolStatuses retrieves the trading status of all symbols on Binance.
+// NOTE: The exchange info endpoint has a high request weight so this should not be called at a high rate.
+func (blc *BinanceListingsChecker) RetrieveSymbolStatuses() (statuses map[string]string, err error) {
+	exchangeInfo, err := blc.BinanceClient.NewExchangeInfoService().Do(context.Background())
+	if err != nil {
+		return nil, blc.EndpointPool.RequestError(err)
+	}
+
+	statuses = make(map[string]string, len(exchangeInfo.Symbols))
+	for _, symbol := range exchangeInfo.Symbols {
+		statuses[symbol.Symbol] = symbol.Status
+	}
+	return statuses, nil
+}
+
+// SymbolURL returns the Binance trading URL of a given symbol.
+func (blc *BinanceListingsChecker) SymbolURL(symbol string) string {
+	return utils.CreateBinanceURL(symbol)
+}
+
+// StreamSymbols streams the symbols that are being traded on Binance using the '!miniTicker@arr' websocket stream.
+func (blc *BinanceListingsChecker) StreamSymbols(handler func(symbols []string), errHandler func(err error)) (doneC chan struct{}, err error) {
+	doneC, _, err = binance.WsAllMiniMarketsStatServe(func(event binance.WsAllMiniMarketsStatEvent) {
+		symbols := make([]string, len(event))
+		for i, marketStat := range event {
+			symbols[i] = marketStat.Symbol
+		}
+		handler(symbols)
+	}, errHandler)
+	return doneC, err
 }
 
 // retrieveSymbolInfo retrieves information about a given symbol from Binance.
-// NOTE: Try for 1 minutes before continuing.
-func (blc *BinanceListingsChecker) retrieveSymbolInfo(symbol string) (assetInfo binance.Symbol) {
+// NOTE: Try for 1 minute if the symbol is not yet available or a transient error occurs before continuing.
+func (blc *BinanceListingsChecker) retrieveSymbolInfo(symbol string) (assetInfo binance.Symbol, err error) {
 	tStart := time.Now()
 	limiter := rate.NewLimiter(rate.Limit(blc.maxRate), 1)
+	retryPolicy := exchanges.NewRetryPolicy(exchanges.DEFAULT_MIN_RETRY_BACKOFF, exchanges.DEFAULT_MAX_RETRY_BACKOFF)
 	for time.Since(tStart) < 1*time.Minute {
+		limiter.SetLimit(rate.Limit(blc.AdaptRate(blc.maxRate)))
 		limiter.Wait(context.Background()) // NOTE: This is to prevent binance from blocking the IP address.
 
 		// Retrieve symbol info from Binance.
 		exchangeInfoService := blc.BinanceClient.NewExchangeInfoService()
 		exchangeInfoService = exchangeInfoService.Symbols(symbol)
-		exchangeInfoTmp, err := exchangeInfoService.Do(context.Background())
-
-		// Retry if symbol was not found (i.e. error -1121).
-		if err != nil {
-			if err.(*common.APIError).Code == -1121 {
-				if time.Since(blc.lastSymbolInfoWarningTime) > 10*time.Second { // Only log every 10 seconds.
-					log.Printf("Warning: Error retrieving Binance symbol info: %v", err)
-					blc.lastSymbolInfoWarningTime = time.Now()
-				}
-				continue
+		exchangeInfoTmp, requestErr := exchangeInfoService.Do(context.Background())
+		if requestErr == nil {
+			if len(exchangeInfoTmp.Symbols) == 0 {
+				return assetInfo, exchanges.NewError(exchanges.ERROR_FATAL, fmt.Errorf("symbol '%s' not found in Binance exchange info", symbol))
 			}
-			log.Fatalf("WARNING: Error retrieving Binance symbol info: %v", err)
+			return exchangeInfoTmp.Symbols[0], nil
 		}
 
-		assetInfo = exchangeInfoTmp.Symbols[0]
-		break
-	}
-
-	return assetInfo
-}
-
-// changedListings checks whether the listings on Binance have changed.
-func (blc *BinanceListingsChecker) changedListings(oldAssets *[]string) (removed bool, changedAssets []string) {
-	assets := blc.retrieveBinanceAssets()
-
-	// Return if no assets are available.
-	if len(assets) == 0 {
-		return false, []string{}
-	}
-
-	// Return changed assets.
-	removed, changedAssets = utils.CompareLists(*oldAssets, assets)
-	*oldAssets = assets
-	return removed, changedAssets
-}
-
-// Post messages in Telegram and Discord if new listings or de-listings are found.
-func (blc *BinanceListingsChecker) postMessages(removed bool, changedAssets []string, oldAssets []string) {
-	for _, asset := range changedAssets {
-		assetInfo := blc.retrieveSymbolInfo(asset)
-
-		// Log new listing or de-listing.
-		if removed {
-			log.Printf("De-listing found: %v", asset)
+		// Retry if symbol was not found (i.e. error -1121) or a transient error occurred.
+		var apiErr *common.APIError
+		err = blc.EndpointPool.RequestError(requestErr)
+		if errors.As(requestErr, &apiErr) && apiErr.Code == -1121 {
+			err = requestErr
+		} else if exchanges.ErrorClass(err) != exchanges.ERROR_TRANSIENT {
+			return assetInfo, err
 		} else {
-			log.Printf("New listing found: %v", asset)
+			time.Sleep(retryPolicy.Failure(err))
+		}
+		if time.Since(blc.lastSymbolInfoWarningTime) > 10*time.Second { // Only log every 10 seconds.
+			log.Printf("WARNING: Error retrieving Binance symbol info: %v", err)
+			blc.lastSymbolInfoWarningTime = time.Now()
 		}
-
-		// Post telegram and discord messages.
-		go messaging.SendAssetMessage(blc.TelegramBot, blc.TelegramChatID, blc.EnableTelegramMessage, blc.DiscordBot, blc.DiscordChannelIDs, blc.EnableDiscordMessages, removed, asset, assetInfo)
-
-		utils.StoreOldListings(oldAssets)
-	}
-}
-
-// Start starts the BinanceListingsChecker.
-func (blc *BinanceListingsChecker) Start(maxRate float64) {
-	blc.maxRate = maxRate
-
-	// Retrieve (old) stored Binance listings.
-	oldAssets := utils.RetrieveOldListings()
-	if len(oldAssets) == 0 { // Get from Binance if no old listings are stored.
-		oldAssets = blc.retrieveBinanceAssets()
-		utils.StoreOldListings(oldAssets)
 	}
 
-	// Check binance for new listings or de-listings and post Telegram/Discord message.
-	limiter := rate.NewLimiter(rate.Limit(maxRate), 1)
-	for {
-		limiter.Wait(context.Background()) // NOTE: This is to prevent binance from blocking the IP address.
-
-		// Check for new listings or de-listings.
-		removed, changedAssets := blc.changedListings(&oldAssets)
+	return assetInfo, err
+}
 
-		// Post messages.
-		go blc.postMessages(removed, changedAssets, oldAssets)
+// RetrieveSymbolInfo retrieves the exchange agnostic information about a given symbol from Binance.
+func (blc *BinanceListingsChecker) RetrieveSymbolInfo(symbol string) (exchanges.SymbolInfo, error) {
+	assetInfo, err := blc.retrieveSymbolInfo(symbol)
+	if err != nil {
+		return exchanges.SymbolInfo{}, err
 	}
+	return exchanges.SymbolInfo{
+		Exchange:   blc.Name(),
+		Symbol:     symbol,
+		BaseAsset:  assetInfo.BaseAsset,
+		QuoteAsset: assetInfo.QuoteAsset,
+		Status:     assetInfo.Status,
+		URL:        blc.SymbolURL(symbol),
+	}, nil
 }
@@ -0,0 +1,212 @@
+// Description: Tests for the binanceListingsChecker package.
+
+package binanceListingsChecker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// TestClassifyError tests that the ClassifyError function classifies the Binance API errors.
+func TestClassifyError(t *testing.T) {
+	bannedUntil := time.Now().Add(time.Hour).UnixMilli()
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{errors.New("connection reset"), exchanges.ERROR_TRANSIENT},
+		{&common.APIError{Code: -1003, Message: "Too much request weight used; current limit is 6000 request weight per 1 MINUTE."}, exchanges.ERROR_RATE_LIMITED},
+		{&common.APIError{Code: -1003, Message: fmt.Sprintf("Way too much request weight used; IP banned until %d.", bannedUntil)}, exchanges.ERROR_BANNED},
+		{&common.APIError{Code: -1001, Message: "Internal error; unable to process your request. Please try again."}, exchanges.ERROR_TRANSIENT},
+		{&common.APIError{Code: -1121, Message: "Invalid symbol."}, exchanges.ERROR_FATAL},
+	}
+	for _, test := range tests {
+		if class := exchanges.ErrorClass(ClassifyError(test.err)); class != test.class {
+			t.Errorf("Expected %s, got %s", test.class, class)
+		}
+	}
+
+	// Check ban expiry.
+	var exchangeErr *exchanges.Error
+	errors.As(ClassifyError(tests[2].err), &exchangeErr)
+	if exchangeErr.RetryAfter < 59*time.Minute || exchangeErr.RetryAfter > time.Hour {
+		t.Errorf("Expected %v, got %v", time.Hour, exchangeErr.RetryAfter)
+	}
+}
+
+// TestRetrieveSymbolInfo tests that the RetrieveSymbolInfo function retries symbols that are not yet available and returns the other errors.
+func TestRetrieveSymbolInfo(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case strings.Contains(r.URL.Query().Get("symbols"), "BANUSDT"):
+			w.WriteHeader(http.StatusTeapot)
+			w.Write([]byte(`{"code":-1003,"msg":"Way too much request weight used; IP banned until 1."}`))
+		case requests == 1:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+		default:
+			w.Write([]byte(`{"symbols":[{"symbol":"FOOUSDT","status":"TRADING","baseAsset":"FOO","quoteAsset":"USDT"}]}`))
+		}
+	}))
+	defer server.Close()
+	client := binance.NewClient("", "")
+	client.BaseURL = server.URL
+	blc, err := NewBinanceListingsChecker(client, 100)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assetInfo, err := blc.RetrieveSymbolInfo("FOOUSDT")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if assetInfo.BaseAsset != "FOO" || assetInfo.QuoteAsset != "USDT" {
+		t.Errorf("Expected %s, got %s", "FOO/USDT", assetInfo.BaseAsset+"/"+assetInfo.QuoteAsset)
+	}
+	if requests != 2 {
+		t.Errorf("Expected %d, got %d", 2, requests)
+	}
+
+	_, err = blc.RetrieveSymbolInfo("BANUSDT")
+	if class := exchanges.ErrorClass(err); class != exchanges.ERROR_BANNED {
+		t.Errorf("Expected %s, got %s", exchanges.ERROR_BANNED, class)
+	}
+}
+
+// TestWeightTracker tests that the WeightTracker lowers the request rate when the weight budget is used up and pauses requests after a 429 response.
+func TestWeightTracker(t *testing.T) {
+	usedWeight := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usedWeight += 4
+		w.Header().Set(USED_WEIGHT_HEADER, fmt.Sprintf("%d", usedWeight))
+		if usedWeight > 8 {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code":-1003,"msg":"Too much request weight used; current limit is 6000 request weight per 1 MINUTE."}`))
+			return
+		}
+		w.Write([]byte(`[{"symbol":"FOOUSDT","price":"1.0"}]`))
+	}))
+	defer server.Close()
+	client := binance.NewClient("", "")
+	client.BaseURL = server.URL
+	blc, err := NewBinanceListingsChecker(client, 1000)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check weight usage.
+	if adaptedRate := blc.AdaptRate(1000); adaptedRate != 1000 {
+		t.Errorf("Expected %v, got %v", 1000, adaptedRate)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := blc.RetrieveSymbols(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	}
+	if used, limit := blc.EndpointPool.sources[0].UsedWeight(); used != 8 || limit != DEFAULT_WEIGHT_LIMIT {
+		t.Errorf("Expected %d/%d, got %d/%d", 8, DEFAULT_WEIGHT_LIMIT, used, limit)
+	}
+	if adaptedRate := blc.AdaptRate(1000); adaptedRate >= 1000 || adaptedRate < MIN_ADAPTED_RATE {
+		t.Errorf("Expected a rate between %v and %v, got %v", MIN_ADAPTED_RATE, 1000, adaptedRate)
+	}
+	blc.EndpointPool.SetWeightBudget(10, 0.8)
+	if adaptedRate := blc.AdaptRate(1000); adaptedRate != MIN_ADAPTED_RATE {
+		t.Errorf("Expected %v, got %v", MIN_ADAPTED_RATE, adaptedRate)
+	}
+
+	// Check that requests are paused after a 429 response.
+	for i := 0; i < 2; i++ {
+		_, err := blc.RetrieveSymbols()
+		var exchangeErr *exchanges.Error
+		if !errors.As(err, &exchangeErr) || exchangeErr.Class != exchanges.ERROR_RATE_LIMITED {
+			t.Errorf("Expected %s error, got %v", exchanges.ERROR_RATE_LIMITED, err)
+		} else if exchangeErr.RetryAfter < 59*time.Second || exchangeErr.RetryAfter > time.Minute {
+			t.Errorf("Expected %v, got %v", time.Minute, exchangeErr.RetryAfter)
+		}
+	}
+	if usedWeight != 12 {
+		t.Errorf("Expected %d, got %d", 12, usedWeight)
+	}
+}
+
+// TestNewBinanceListingsCheckerInvalidEndpoint tests that a invalid Binance client endpoint is returned as a error instead of installing a
+// unusable endpoint pool.
+func TestNewBinanceListingsCheckerInvalidEndpoint(t *testing.T) {
+	client := binance.NewClient("", "")
+	client.BaseURL = "api.binance.com"
+	if blc, err := NewBinanceListingsChecker(client, 100); err == nil || blc != nil {
+		t.Errorf("Expected error, got %v", blc)
+	}
+}
+
+// TestEndpointPool tests that the EndpointPool retries failed requests on another endpoint and skips unhealthy endpoints.
+func TestEndpointPool(t *testing.T) {
+	failingRequests, workingRequests := 0, 0
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failingRequests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failingServer.Close()
+	workingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		workingRequests++
+		w.Write([]byte(`[{"symbol":"FOOUSDT","price":"1.0"}]`))
+	}))
+	defer workingServer.Close()
+	client := binance.NewClient("", "")
+	blc, err := NewBinanceListingsChecker(client, 100)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	err = blc.EndpointPool.SetEndpoints([]string{failingServer.URL, workingServer.URL}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		symbols, err := blc.RetrieveSymbols()
+		if err != nil || len(symbols) != 1 {
+			t.Errorf("Expected %v, got %v (%v)", []string{"FOOUSDT"}, symbols, err)
+		}
+	}
+	if failingRequests != 1 || workingRequests != 4 {
+		t.Errorf("Expected %d/%d, got %d/%d", 1, 4, failingRequests, workingRequests)
+	}
+}
+
+// TestSetEndpoints tests that the SetEndpoints function rejects invalid endpoints and source addresses.
+func TestSetEndpoints(t *testing.T) {
+	ep, err := NewEndpointPool("https://api.binance.com")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	tests := []struct {
+		endpoints []string
+		sources   []string
+		valid     bool
+	}{
+		{[]string{"https://api1.binance.com", "https://data-api.binance.vision"}, []string{"127.0.0.1", "socks5://127.0.0.1:1080"}, true},
+		{nil, nil, false},
+		{[]string{"api1.binance.com"}, nil, false},
+		{[]string{"https://api1.binance.com"}, []string{"localhost"}, false},
+	}
+	for _, test := range tests {
+		if err := ep.SetEndpoints(test.endpoints, test.sources); (err == nil) != test.valid {
+			t.Errorf("Expected valid %v, got %v", test.valid, err)
+		}
+	}
+	if len(ep.routes) != 4 || ep.routes[1].source.name != "socks5://127.0.0.1:1080" {
+		t.Errorf("Expected %d interleaved routes, got %d", 4, len(ep.routes))
+	}
+}
@@ -0,0 +1,174 @@
+package binanceListingsChecker
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// Binance request weight settings.
+// NOTE: The request weight limit is shared by all requests of a IP address and resets every minute (see https://binance-docs.github.io/apidocs/spot/en/#limits).
+const (
+	DEFAULT_WEIGHT_LIMIT  = 6000
+	DEFAULT_WEIGHT_BUDGET = 0.8 // Fraction of the weight limit that the checkers may use.
+	USED_WEIGHT_HEADER    = "X-MBX-USED-WEIGHT-1M"
+	MIN_ADAPTED_RATE      = 0.1 // Request rate that is used when the weight budget is used up.
+	WEIGHT_LOG_INTERVAL   = 5 * time.Minute
+	SAPI_WEIGHT_HEADER    = "X-SAPI-USED-IP-WEIGHT-1M" // Used by the '/sapi' endpoints (e.g. margin and earn).
+)
+
+// WeightTracker is a class that tracks the Binance request weight usage and rate limit responses. It implements the http.RoundTripper interface.
+// NOTE: Requests are not sent while Binance asked to wait (i.e. after a 418 or 429 response) since this extends the ban.
+type WeightTracker struct {
+	name          string // Name of the source address.
+	transport     http.RoundTripper
+	weightHeader  string
+	weightLimit   int
+	weightBudget  float64
+	usedWeight    int
+	weightTime    time.Time
+	requestWeight float64 // Moving average of the weight per request.
+	peakWeight    int
+	lastLogTime   time.Time
+	retryUntil    time.Time
+	retryClass    string
+	mutex         sync.Mutex
+}
+
+// NewWeightTracker creates a new WeightTracker for a source address that sends the requests using the given transport.
+func NewWeightTracker(name string, transport http.RoundTripper) *WeightTracker {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &WeightTracker{
+		name:         name,
+		transport:    transport,
+		weightHeader: USED_WEIGHT_HEADER,
+		weightLimit:  DEFAULT_WEIGHT_LIMIT,
+		weightBudget: DEFAULT_WEIGHT_BUDGET,
+		lastLogTime:  time.Now(),
+	}
+}
+
+// SetWeightBudget sets the request weight limit and the fraction of it that may be used.
+func (wt *WeightTracker) SetWeightBudget(weightLimit int, weightBudget float64) {
+	wt.mutex.Lock()
+	defer wt.mutex.Unlock()
+	wt.weightLimit = weightLimit
+	wt.weightBudget = weightBudget
+}
+
+// SetWeightHeader sets the response header that contains the used request weight.
+func (wt *WeightTracker) SetWeightHeader(weightHeader string) {
+	wt.mutex.Lock()
+	defer wt.mutex.Unlock()
+	wt.weightHeader = weightHeader
+}
+
+// UsedWeight returns the request weight that was used in the current minute and the weight limit.
+func (wt *WeightTracker) UsedWeight() (usedWeight int, weightLimit int) {
+	wt.mutex.Lock()
+	defer wt.mutex.Unlock()
+	return wt.currentWeight(time.Now()), wt.weightLimit
+}
+
+// currentWeight returns the used request weight or zero if it was reported in a previous minute.
+func (wt *WeightTracker) currentWeight(now time.Time) int {
+	if !now.Truncate(time.Minute).Equal(wt.weightTime.Truncate(time.Minute)) {
+		return 0
+	}
+	return wt.usedWeight
+}
+
+// RetryAfter returns the time that Binance asked to wait before the next request.
+func (wt *WeightTracker) RetryAfter() time.Duration {
+	wt.mutex.Lock()
+	defer wt.mutex.Unlock()
+	return time.Until(wt.retryUntil)
+}
+
+// AdaptRate returns the request rate that keeps the request weight usage within budget until the weight resets.
+// NOTE: The max rate is returned until the weight per request is known.
+func (wt *WeightTracker) AdaptRate(maxRate float64) float64 {
+	wt.mutex.Lock()
+	defer wt.mutex.Unlock()
+	if wt.requestWeight == 0 {
+		return maxRate
+	}
+
+	now := time.Now()
+	remainingWeight := wt.weightBudget*float64(wt.weightLimit) - float64(wt.currentWeight(now))
+	resetTime := now.Truncate(time.Minute).Add(time.Minute)
+	adaptedRate := remainingWeight / wt.requestWeight / resetTime.Sub(now).Seconds()
+	if adaptedRate < MIN_ADAPTED_RATE {
+		adaptedRate = MIN_ADAPTED_RATE
+	}
+	if adaptedRate > maxRate {
+		return maxRate
+	}
+	return adaptedRate
+}
+
+// RoundTrip sends a request and records the request weight usage and rate limit responses.
+func (wt *WeightTracker) RoundTrip(request *http.Request) (*http.Response, error) {
+	wt.mutex.Lock()
+	if retryAfter := time.Until(wt.retryUntil); retryAfter > 0 {
+		retryClass := wt.retryClass
+		wt.mutex.Unlock()
+		return nil, &exchanges.Error{Class: retryClass, RetryAfter: retryAfter, Err: fmt.Errorf("binance requests from %s paused for %v", wt.name, retryAfter.Round(time.Second))}
+	}
+	wt.mutex.Unlock()
+
+	response, err := wt.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	wt.update(response, time.Now())
+	return response, nil
+}
+
+// update records the request weight usage and the 'Retry-After' header of a response.
+func (wt *WeightTracker) update(response *http.Response, now time.Time) {
+	wt.mutex.Lock()
+	defer wt.mutex.Unlock()
+
+	// Store the used request weight.
+	usedWeight, err := strconv.Atoi(response.Header.Get(wt.weightHeader))
+	if err == nil {
+		oldWeight := wt.currentWeight(now)
+		if usedWeight > oldWeight && oldWeight != 0 {
+			weight := float64(usedWeight - oldWeight)
+			if wt.requestWeight == 0 {
+				wt.requestWeight = weight
+			} else {
+				wt.requestWeight = 0.8*wt.requestWeight + 0.2*weight
+			}
+		}
+		wt.usedWeight = usedWeight
+		wt.weightTime = now
+		if usedWeight > wt.peakWeight {
+			wt.peakWeight = usedWeight
+		}
+	}
+	if now.Sub(wt.lastLogTime) > WEIGHT_LOG_INTERVAL {
+		log.Printf("Binance peak request weight usage of %s in the last %v: %d/%d", wt.name, WEIGHT_LOG_INTERVAL, wt.peakWeight, wt.weightLimit)
+		wt.peakWeight = 0
+		wt.lastLogTime = now
+	}
+
+	// Pause requests when Binance asks to.
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusTeapot {
+		retryAfter := exchanges.ParseRetryAfter(response.Header.Get("Retry-After"), now)
+		if retryAfter <= 0 {
+			retryAfter = exchanges.DEFAULT_MIN_RETRY_BACKOFF
+		}
+		wt.retryUntil = now.Add(retryAfter)
+		wt.retryClass = exchanges.ClassifyStatusCode(response.StatusCode)
+		log.Printf("WARNING: Binance responded with status code %d (request weight %d/%d), pausing requests from %s for %v.", response.StatusCode, wt.usedWeight, wt.weightLimit, wt.name, retryAfter)
+	}
+}
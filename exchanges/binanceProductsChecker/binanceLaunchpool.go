@@ -0,0 +1,103 @@
+package binanceProductsChecker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/valyala/fasthttp"
+)
+
+// LAUNCHPOOL_ENDPOINT is the (unofficial) Binance Launchpool projects endpoint.
+// NOTE: Binance has no official Launchpool API so the endpoint used by the Binance website is used.
+const LAUNCHPOOL_ENDPOINT = "https://www.binance.com/bapi/earn/v1/friendly/launchpool/project/listV3"
+
+// BinanceLaunchpoolProjects represents the Binance Launchpool projects response.
+type BinanceLaunchpoolProjects struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Success bool            `json:"success"`
+}
+
+// BinanceLaunchpoolPool represents a Binance Launchpool pool in which a asset is staked to farm the reward asset of a project.
+type BinanceLaunchpoolPool struct {
+	ProjectID  string `json:"projectId"`
+	RebateCoin string `json:"rebateCoin"` // Reward asset.
+	Asset      string `json:"asset"`      // Staked asset.
+	Status     string `json:"status"`
+}
+
+// findLaunchpoolPools returns the pools in a (unofficial) Launchpool response.
+// NOTE: The pools are searched recursively since the layout of the unofficial response is not documented.
+func findLaunchpoolPools(data interface{}) (pools []BinanceLaunchpoolPool) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if rebateCoin, ok := value["rebateCoin"].(string); ok && rebateCoin != "" {
+			pool := BinanceLaunchpoolPool{RebateCoin: rebateCoin}
+			pool.ProjectID, _ = value["projectId"].(string)
+			pool.Asset, _ = value["asset"].(string)
+			pool.Status, _ = value["status"].(string)
+			return []BinanceLaunchpoolPool{pool}
+		}
+		for _, child := range value {
+			pools = append(pools, findLaunchpoolPools(child)...)
+		}
+	case []interface{}:
+		for _, child := range value {
+			pools = append(pools, findLaunchpoolPools(child)...)
+		}
+	}
+	return pools
+}
+
+// NewBinanceLaunchpoolChecker creates a new BinanceProductsChecker that retrieves the reward assets of the Binance Launchpool projects.
+func NewBinanceLaunchpoolChecker() *BinanceProductsChecker {
+	bpc := newBinanceProductsChecker(exchanges.PRODUCT_LAUNCHPOOL)
+	bpc.SetLaunchpoolEndpoint(LAUNCHPOOL_ENDPOINT)
+	return bpc
+}
+
+// SetLaunchpoolEndpoint sets the (unofficial) Binance Launchpool projects endpoint.
+func (bpc *BinanceProductsChecker) SetLaunchpoolEndpoint(endpoint string) {
+	bpc.retrieveProducts = func() ([]exchanges.SymbolInfo, error) {
+		request := fasthttp.AcquireRequest()
+		response := fasthttp.AcquireResponse()
+		defer fasthttp.ReleaseRequest(request)
+		defer fasthttp.ReleaseResponse(response)
+
+		// Make request.
+		request.SetRequestURI(endpoint)
+		request.Header.SetMethod("GET")
+		request.Header.Set("Content-Type", "application/json")
+		err := fasthttp.Do(request, response)
+		if err != nil {
+			return nil, fmt.Errorf("error scraping binance launchpool endpoint: %w", err)
+		}
+		if response.StatusCode() != 200 {
+			return nil, exchanges.StatusError(response.StatusCode(), "Binance launchpool")
+		}
+
+		// Unmarshal response.
+		var projects BinanceLaunchpoolProjects
+		if err := json.Unmarshal(response.Body(), &projects); err != nil {
+			return nil, fmt.Errorf("error unmarshalling binance launchpool response: %w", err)
+		}
+		var data interface{}
+		if err := json.Unmarshal(projects.Data, &data); err != nil || data == nil {
+			return nil, exchanges.NewError(exchanges.ERROR_TRANSIENT, fmt.Errorf("binance launchpool response contains no data (code %s: %s)", projects.Code, projects.Message))
+		}
+
+		// Store the reward asset of each project.
+		// NOTE: A project has a pool for each staked asset.
+		var assets []exchanges.SymbolInfo
+		found := make(map[string]bool)
+		for _, pool := range findLaunchpoolPools(data) {
+			if !found[pool.RebateCoin] {
+				found[pool.RebateCoin] = true
+				assets = append(assets, bpc.symbolInfo(pool.RebateCoin, pool.RebateCoin, "", pool.Status))
+			}
+		}
+		return assets, nil
+	}
+}
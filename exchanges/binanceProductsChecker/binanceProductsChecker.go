@@ -0,0 +1,297 @@
+// Description: Package binanceProductsChecker contains a class that retrieves the assets of the Binance margin, earn and launchpool products. It implements the exchanges.ListingSource interface.
+package binanceProductsChecker
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/binanceListingsChecker"
+)
+
+// SAPI_WEIGHT_LIMIT is the request weight limit per minute of the Binance '/sapi' endpoints.
+const SAPI_WEIGHT_LIMIT = 12000
+
+// Binance Simple Earn settings.
+const (
+	EARN_PAGE_SIZE     = 100 // Max page size of the Simple Earn product lists.
+	MAX_EARN_PAGES     = 20
+	EARN_FLEXIBLE_LIST = "/sapi/v1/simple-earn/flexible/list"
+	EARN_LOCKED_LIST   = "/sapi/v1/simple-earn/locked/list"
+)
+
+// PRODUCT_NAMES contains the names of the supported Binance products.
+var PRODUCT_NAMES = map[string]string{
+	exchanges.PRODUCT_CROSS_MARGIN:    "Binance Cross Margin",
+	exchanges.PRODUCT_ISOLATED_MARGIN: "Binance Isolated Margin",
+	exchanges.PRODUCT_SIMPLE_EARN:     "Binance Simple Earn",
+	exchanges.PRODUCT_LAUNCHPOOL:      "Binance Launchpool",
+}
+
+// PRODUCT_URLS contains the Binance web pages of the supported products.
+var PRODUCT_URLS = map[string]string{
+	exchanges.PRODUCT_CROSS_MARGIN:    "https://www.binance.com/en/margin-fee",
+	exchanges.PRODUCT_ISOLATED_MARGIN: "https://www.binance.com/en/margin-fee",
+	exchanges.PRODUCT_SIMPLE_EARN:     "https://www.binance.com/en/simple-earn",
+	exchanges.PRODUCT_LAUNCHPOOL:      "https://www.binance.com/en/launchpool",
+}
+
+// BinanceEarnProducts represents a page of a Binance Simple Earn product list.
+type BinanceEarnProducts struct {
+	Rows []struct {
+		Asset  string `json:"asset"` // Flexible products only.
+		Status string `json:"status"`
+		Detail struct {
+			Asset  string `json:"asset"`
+			Status string `json:"status"`
+		} `json:"detail"` // Locked products only.
+	} `json:"rows"`
+	Total int `json:"total"`
+}
+
+// BinanceProductsChecker is a class that retrieves the assets of a Binance product.
+type BinanceProductsChecker struct {
+	name             string
+	product          string
+	EndpointPool     *binanceListingsChecker.EndpointPool
+	retrieveProducts func() ([]exchanges.SymbolInfo, error)
+	products         map[string]exchanges.SymbolInfo
+	mu               sync.RWMutex
+}
+
+// newBinanceProductsChecker creates a new BinanceProductsChecker for a given product.
+func newBinanceProductsChecker(product string) *BinanceProductsChecker {
+	return &BinanceProductsChecker{
+		name:     PRODUCT_NAMES[product],
+		product:  product,
+		products: make(map[string]exchanges.SymbolInfo),
+	}
+}
+
+// newBinanceSAPIProductsChecker creates a new BinanceProductsChecker that sends the '/sapi' requests of a Binance client through a endpoint pool.
+// NOTE: The '/sapi' endpoints are not served by the market data endpoints (e.g. data-api.binance.vision) so the pool only contains the Binance client endpoint.
+func newBinanceSAPIProductsChecker(product string, binanceClient *binance.Client) (*BinanceProductsChecker, error) {
+	bpc := newBinanceProductsChecker(product)
+	endpointPool, err := binanceListingsChecker.NewEndpointPool(binanceClient.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s endpoint pool: %w", bpc.name, err)
+	}
+	endpointPool.SetWeightHeader(binanceListingsChecker.SAPI_WEIGHT_HEADER)
+	endpointPool.SetWeightBudget(SAPI_WEIGHT_LIMIT, binanceListingsChecker.DEFAULT_WEIGHT_BUDGET)
+	httpClient := *binanceClient.HTTPClient
+	httpClient.Transport = endpointPool
+	binanceClient.HTTPClient = &httpClient
+	bpc.EndpointPool = endpointPool
+	return bpc, nil
+}
+
+// NewBinanceCrossMarginChecker creates a new BinanceProductsChecker that retrieves the assets that can be borrowed on cross margin.
+// NOTE: Requires a Binance API key.
+func NewBinanceCrossMarginChecker(binanceClient *binance.Client) (*BinanceProductsChecker, error) {
+	bpc, err := newBinanceSAPIProductsChecker(exchanges.PRODUCT_CROSS_MARGIN, binanceClient)
+	if err != nil {
+		return nil, err
+	}
+	bpc.retrieveProducts = func() ([]exchanges.SymbolInfo, error) {
+		marginAssets, err := binanceClient.NewGetAllMarginAssetsService().Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		var assets []exchanges.SymbolInfo
+		for _, marginAsset := range marginAssets {
+			if marginAsset.Borrowable {
+				assets = append(assets, bpc.symbolInfo(marginAsset.Name, marginAsset.Name, "", "BORROWABLE"))
+			}
+		}
+		return assets, nil
+	}
+	return bpc, nil
+}
+
+// NewBinanceIsolatedMarginChecker creates a new BinanceProductsChecker that retrieves the pairs that can be traded on isolated margin.
+// NOTE: Requires a Binance API key.
+func NewBinanceIsolatedMarginChecker(binanceClient *binance.Client) (*BinanceProductsChecker, error) {
+	bpc, err := newBinanceSAPIProductsChecker(exchanges.PRODUCT_ISOLATED_MARGIN, binanceClient)
+	if err != nil {
+		return nil, err
+	}
+	bpc.retrieveProducts = func() ([]exchanges.SymbolInfo, error) {
+		marginPairs, err := binanceClient.NewGetIsolatedMarginAllPairsService().Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		var pairs []exchanges.SymbolInfo
+		for _, marginPair := range marginPairs {
+			if marginPair.IsMarginTrade {
+				pairs = append(pairs, bpc.symbolInfo(marginPair.Symbol, marginPair.Base, marginPair.Quote, "MARGIN_TRADE"))
+			}
+		}
+		return pairs, nil
+	}
+	return bpc, nil
+}
+
+// NewBinanceSimpleEarnChecker creates a new BinanceProductsChecker that retrieves the assets of the flexible and locked Simple Earn products.
+// NOTE: Requires a Binance API key and secret since the Simple Earn endpoints are signed.
+func NewBinanceSimpleEarnChecker(binanceClient *binance.Client) (*BinanceProductsChecker, error) {
+	bpc, err := newBinanceSAPIProductsChecker(exchanges.PRODUCT_SIMPLE_EARN, binanceClient)
+	if err != nil {
+		return nil, err
+	}
+	bpc.retrieveProducts = func() ([]exchanges.SymbolInfo, error) {
+		var assets []exchanges.SymbolInfo
+		found := make(map[string]bool)
+		for _, endpoint := range []string{EARN_FLEXIBLE_LIST, EARN_LOCKED_LIST} {
+			for page, retrieved := 1, 0; page <= MAX_EARN_PAGES; page++ {
+				var earnProducts BinanceEarnProducts
+				params := url.Values{"current": {strconv.Itoa(page)}, "size": {strconv.Itoa(EARN_PAGE_SIZE)}}
+				if err := signedGet(binanceClient, endpoint, params, &earnProducts); err != nil {
+					return nil, err
+				}
+
+				// Store the assets that were not found yet.
+				for _, row := range earnProducts.Rows {
+					asset, status := row.Asset, row.Status
+					if asset == "" {
+						asset, status = row.Detail.Asset, row.Detail.Status
+					}
+					if asset != "" && !found[asset] {
+						found[asset] = true
+						assets = append(assets, bpc.symbolInfo(asset, asset, "", status))
+					}
+				}
+				retrieved += len(earnProducts.Rows)
+				if len(earnProducts.Rows) == 0 || retrieved >= earnProducts.Total {
+					break
+				}
+			}
+		}
+		return assets, nil
+	}
+	return bpc, nil
+}
+
+// NewBinanceProductsChecker creates a new BinanceProductsChecker for a given product.
+func NewBinanceProductsChecker(product string, apiKey string, secretKey string) (*BinanceProductsChecker, error) {
+	switch product {
+	case exchanges.PRODUCT_CROSS_MARGIN:
+		return NewBinanceCrossMarginChecker(binance.NewClient(apiKey, secretKey))
+	case exchanges.PRODUCT_ISOLATED_MARGIN:
+		return NewBinanceIsolatedMarginChecker(binance.NewClient(apiKey, secretKey))
+	case exchanges.PRODUCT_SIMPLE_EARN:
+		return NewBinanceSimpleEarnChecker(binance.NewClient(apiKey, secretKey))
+	case exchanges.PRODUCT_LAUNCHPOOL:
+		return NewBinanceLaunchpoolChecker(), nil
+	default:
+		return nil, fmt.Errorf("unknown Binance product '%s'", product)
+	}
+}
+
+// signedGet sends a signed GET request to a Binance endpoint that is not supported by the go-binance client and unmarshals the response into v.
+// NOTE: Error responses are returned as go-binance API errors so that they are classified like the other Binance errors.
+func signedGet(binanceClient *binance.Client, endpoint string, params url.Values, v interface{}) error {
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli()-binanceClient.TimeOffset, 10))
+	mac := hmac.New(sha256.New, []byte(binanceClient.SecretKey))
+	mac.Write([]byte(params.Encode()))
+	query := params.Encode() + "&signature=" + hex.EncodeToString(mac.Sum(nil))
+
+	// Make request.
+	request, err := http.NewRequest(http.MethodGet, binanceClient.BaseURL+endpoint+"?"+query, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("X-MBX-APIKEY", binanceClient.APIKey)
+	response, err := binanceClient.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode >= 400 {
+		apiErr := new(common.APIError)
+		if json.Unmarshal(body, apiErr) != nil || apiErr.Code == 0 {
+			return exchanges.StatusError(response.StatusCode, fmt.Sprintf("Binance '%s'", endpoint))
+		}
+		return apiErr
+	}
+
+	// Unmarshal response.
+	return json.Unmarshal(body, v)
+}
+
+// symbolInfo returns the exchange agnostic information of a product asset.
+func (bpc *BinanceProductsChecker) symbolInfo(symbol string, baseAsset string, quoteAsset string, status string) exchanges.SymbolInfo {
+	return exchanges.SymbolInfo{
+		Exchange:   bpc.name,
+		Symbol:     symbol,
+		BaseAsset:  baseAsset,
+		QuoteAsset: quoteAsset,
+		Status:     status,
+		URL:        PRODUCT_URLS[bpc.product],
+		Product:    bpc.product,
+	}
+}
+
+// Name returns the name of the product.
+func (bpc *BinanceProductsChecker) Name() string {
+	return bpc.name
+}
+
+// RetrieveSymbols retrieves a list with the assets of the product.
+func (bpc *BinanceProductsChecker) RetrieveSymbols() (symbols []string, err error) {
+	products, err := bpc.retrieveProducts()
+	if err != nil {
+		if bpc.EndpointPool != nil {
+			return nil, bpc.EndpointPool.RequestError(err)
+		}
+		return nil, err
+	}
+
+	// Cache products.
+	symbols = make([]string, len(products))
+	bpc.mu.Lock()
+	defer bpc.mu.Unlock()
+	for i, product := range products {
+		symbols[i] = product.Symbol
+		bpc.products[product.Symbol] = product
+	}
+	return symbols, nil
+}
+
+// RetrieveSymbolInfo retrieves the exchange agnostic information about a given product asset.
+// NOTE: Uses the information retrieved by the last RetrieveSymbols call.
+func (bpc *BinanceProductsChecker) RetrieveSymbolInfo(symbol string) (exchanges.SymbolInfo, error) {
+	bpc.mu.RLock()
+	defer bpc.mu.RUnlock()
+	product, ok := bpc.products[symbol]
+	if !ok {
+		return exchanges.SymbolInfo{}, fmt.Errorf("asset '%s' not found on %s", symbol, bpc.name)
+	}
+
+	return product, nil
+}
+
+// AdaptRate returns the request rate that keeps the Binance '/sapi' request weight usage within budget.
+func (bpc *BinanceProductsChecker) AdaptRate(maxRate float64) float64 {
+	if bpc.EndpointPool == nil {
+		return maxRate
+	}
+	return bpc.EndpointPool.AdaptRate(maxRate)
+}
@@ -0,0 +1,160 @@
+// Description: Tests for the binanceProductsChecker package.
+
+package binanceProductsChecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// newBinanceClient returns a Binance client that sends its requests to a given server.
+func newBinanceClient(server *httptest.Server) *binance.Client {
+	client := binance.NewClient("key", "secret")
+	client.BaseURL = server.URL
+	return client
+}
+
+// TestCrossMarginProducts tests that only the borrowable cross margin assets are retrieved.
+func TestCrossMarginProducts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sapi/v1/margin/allAssets" {
+			t.Errorf("Expected %s, got %s", "/sapi/v1/margin/allAssets", r.URL.Path)
+		}
+		if r.Header.Get("X-MBX-APIKEY") != "key" {
+			t.Errorf("Expected %s, got %s", "key", r.Header.Get("X-MBX-APIKEY"))
+		}
+		w.Write([]byte(`[{"assetName":"FOO","isBorrowable":true},{"assetName":"BAR","isBorrowable":false}]`))
+	}))
+	defer server.Close()
+	bpc, err := NewBinanceCrossMarginChecker(newBinanceClient(server))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	symbols, err := bpc.RetrieveSymbols()
+	if err != nil || len(symbols) != 1 || symbols[0] != "FOO" {
+		t.Errorf("Expected %v, got %v (%v)", []string{"FOO"}, symbols, err)
+	}
+	asset, err := bpc.RetrieveSymbolInfo("FOO")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if !asset.IsProduct() || asset.Product != exchanges.PRODUCT_CROSS_MARGIN || asset.Exchange != "Binance Cross Margin" {
+		t.Errorf("Expected %s, got %s", "Binance Cross Margin", asset.Exchange)
+	}
+}
+
+// TestIsolatedMarginProducts tests that the isolated margin pairs are retrieved.
+func TestIsolatedMarginProducts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"symbol":"FOOUSDT","base":"FOO","quote":"USDT","isMarginTrade":true},{"symbol":"BARUSDT","base":"BAR","quote":"USDT","isMarginTrade":false}]`))
+	}))
+	defer server.Close()
+	bpc, err := NewBinanceIsolatedMarginChecker(newBinanceClient(server))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	symbols, err := bpc.RetrieveSymbols()
+	if err != nil || len(symbols) != 1 || symbols[0] != "FOOUSDT" {
+		t.Errorf("Expected %v, got %v (%v)", []string{"FOOUSDT"}, symbols, err)
+	}
+	asset, _ := bpc.RetrieveSymbolInfo("FOOUSDT")
+	if asset.BaseAsset != "FOO" || asset.QuoteAsset != "USDT" {
+		t.Errorf("Expected %s, got %s", "FOO/USDT", asset.BaseAsset+"/"+asset.QuoteAsset)
+	}
+}
+
+// TestSimpleEarnProducts tests that the signed flexible and locked Simple Earn product lists are paged through and merged.
+func TestSimpleEarnProducts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("signature") == "" || query.Get("timestamp") == "" {
+			t.Errorf("Expected signed request, got %s", r.URL.RawQuery)
+		}
+		switch {
+		case r.URL.Path == EARN_FLEXIBLE_LIST && query.Get("current") == "1":
+			w.Write([]byte(`{"rows":[{"asset":"FOO","status":"PURCHASING"}],"total":2}`))
+		case r.URL.Path == EARN_FLEXIBLE_LIST && query.Get("current") == "2":
+			w.Write([]byte(`{"rows":[{"asset":"BAR","status":"PURCHASING"}],"total":2}`))
+		case r.URL.Path == EARN_LOCKED_LIST:
+			w.Write([]byte(`{"rows":[{"projectId":"Foo*30","detail":{"asset":"FOO","status":"CREATED"}},{"projectId":"Baz*90","detail":{"asset":"BAZ","status":"CREATED"}}],"total":2}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	bpc, err := NewBinanceSimpleEarnChecker(newBinanceClient(server))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	symbols, err := bpc.RetrieveSymbols()
+	if err != nil || len(symbols) != 3 || symbols[0] != "FOO" || symbols[1] != "BAR" || symbols[2] != "BAZ" {
+		t.Errorf("Expected %v, got %v (%v)", []string{"FOO", "BAR", "BAZ"}, symbols, err)
+	}
+	asset, _ := bpc.RetrieveSymbolInfo("BAZ")
+	if asset.Status != "CREATED" {
+		t.Errorf("Expected %s, got %s", "CREATED", asset.Status)
+	}
+}
+
+// TestSimpleEarnError tests that Binance error responses are classified.
+func TestSimpleEarnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`))
+	}))
+	defer server.Close()
+	bpc, err := NewBinanceSimpleEarnChecker(newBinanceClient(server))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := bpc.RetrieveSymbols(); exchanges.ErrorClass(err) != exchanges.ERROR_FATAL {
+		t.Errorf("Expected %s, got %s (%v)", exchanges.ERROR_FATAL, exchanges.ErrorClass(err), err)
+	}
+}
+
+// TestLaunchpoolProducts tests that the reward assets are found in the (unofficial) Launchpool response.
+func TestLaunchpoolProducts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"000000","data":{"tracking":{"list":[{"projectId":"FOO","projects":[{"rebateCoin":"FOO","asset":"BNB","status":"ONGOING"},{"rebateCoin":"FOO","asset":"FDUSD","status":"ONGOING"}]}]},"completed":[{"rebateCoin":"BAR","asset":"BNB","status":"COMPLETED"}]},"success":true}`))
+	}))
+	defer server.Close()
+	bpc := NewBinanceLaunchpoolChecker()
+	bpc.SetLaunchpoolEndpoint(server.URL)
+
+	symbols, err := bpc.RetrieveSymbols()
+	if err != nil || len(symbols) != 2 {
+		t.Errorf("Expected %d symbols, got %v (%v)", 2, symbols, err)
+	}
+	asset, err := bpc.RetrieveSymbolInfo("FOO")
+	if err != nil || asset.Exchange != "Binance Launchpool" || asset.Status != "ONGOING" {
+		t.Errorf("Expected %s, got %v (%v)", "Binance Launchpool (ONGOING)", asset, err)
+	}
+}
+
+// TestNewBinanceProductsChecker tests that unknown products are rejected.
+func TestNewBinanceProductsChecker(t *testing.T) {
+	if _, err := NewBinanceProductsChecker("options", "", ""); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	bpc, err := NewBinanceProductsChecker(exchanges.PRODUCT_SIMPLE_EARN, "", "")
+	if err != nil || bpc.Name() != "Binance Simple Earn" {
+		t.Errorf("Expected %s, got %v (%v)", "Binance Simple Earn", bpc, err)
+	}
+}
+
+// TestInvalidEndpoint tests that a invalid Binance client endpoint is returned as a error instead of installing a unusable endpoint pool.
+func TestInvalidEndpoint(t *testing.T) {
+	client := binance.NewClient("", "")
+	client.BaseURL = "api.binance.com"
+	if bpc, err := NewBinanceCrossMarginChecker(client); err == nil || bpc != nil {
+		t.Errorf("Expected error, got %v", bpc)
+	}
+}
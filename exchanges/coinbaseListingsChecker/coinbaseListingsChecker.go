@@ -0,0 +1,109 @@
+// Description: Package coinbaseListingsChecker contains a class that retrieves the Coinbase Exchange listings. It implements the exchanges.ListingSource interface.
+package coinbaseListingsChecker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+	"github.com/valyala/fasthttp"
+)
+
+// COINBASE_API_ENDPOINT is the default Coinbase Exchange REST API endpoint.
+const COINBASE_API_ENDPOINT = "https://api.exchange.coinbase.com"
+
+// CoinbaseProduct represents a Coinbase Exchange product (i.e. trading pair).
+type CoinbaseProduct struct {
+	ID              string `json:"id"`
+	BaseCurrency    string `json:"base_currency"`
+	QuoteCurrency   string `json:"quote_currency"`
+	DisplayName     string `json:"display_name"`
+	Status          string `json:"status"`
+	StatusMessage   string `json:"status_message"`
+	TradingDisabled bool   `json:"trading_disabled"`
+}
+
+// CoinbaseListingsChecker is a class that retrieves the Coinbase Exchange listings and product information.
+type CoinbaseListingsChecker struct {
+	apiEndpoint string
+}
+
+// NewCoinbaseListingsChecker creates a new CoinbaseListingsChecker.
+func NewCoinbaseListingsChecker() *CoinbaseListingsChecker {
+	return &CoinbaseListingsChecker{
+		apiEndpoint: COINBASE_API_ENDPOINT,
+	}
+}
+
+// SetApiEndpoint sets the Coinbase Exchange REST API endpoint.
+func (clc *CoinbaseListingsChecker) SetApiEndpoint(apiEndpoint string) {
+	clc.apiEndpoint = apiEndpoint
+}
+
+// Name returns the name of the exchange.
+func (clc *CoinbaseListingsChecker) Name() string {
+	return "Coinbase"
+}
+
+// get performs a GET request on the Coinbase Exchange API and unmarshals the response into v.
+func (clc *CoinbaseListingsChecker) get(endpoint string, v interface{}) error {
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	// NOTE: Coinbase rejects requests without a User-Agent header.
+	request.SetRequestURI(clc.apiEndpoint + endpoint)
+	request.Header.SetMethod("GET")
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.SetUserAgent("crypto-listings-sniper")
+	err := fasthttp.Do(request, response)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		return exchanges.StatusError(response.StatusCode(), fmt.Sprintf("Coinbase '%s'", endpoint))
+	}
+
+	// Unmarshal response.
+	return json.Unmarshal(response.Body(), v)
+}
+
+// RetrieveSymbols retrieves a list with the available trading pairs from Coinbase.
+// NOTE: Products that are not online or have trading disabled are excluded since Coinbase keeps the delisted products in the list.
+func (clc *CoinbaseListingsChecker) RetrieveSymbols() (symbols []string, err error) {
+	var products []CoinbaseProduct
+	err = clc.get("/products", &products)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return trading pairs.
+	for _, product := range products {
+		if product.Status != "online" || product.TradingDisabled {
+			continue
+		}
+		symbols = append(symbols, product.ID)
+	}
+	return symbols, nil
+}
+
+// RetrieveSymbolInfo retrieves the exchange agnostic information about a given trading pair from Coinbase.
+func (clc *CoinbaseListingsChecker) RetrieveSymbolInfo(symbol string) (exchanges.SymbolInfo, error) {
+	var product CoinbaseProduct
+	err := clc.get("/products/"+symbol, &product)
+	if err != nil {
+		return exchanges.SymbolInfo{}, err
+	}
+
+	return exchanges.SymbolInfo{
+		Exchange:   clc.Name(),
+		Symbol:     product.ID,
+		BaseAsset:  product.BaseCurrency,
+		QuoteAsset: product.QuoteCurrency,
+		Status:     product.Status,
+		URL:        utils.CreateCoinbaseURL(product.ID),
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Description: Tests for the coinbaseListingsChecker package.
+
+package coinbaseListingsChecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newCoinbaseStandIn returns a local HTTP server that stands in for the Coinbase Exchange API.
+func newCoinbaseStandIn() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/products", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"BTC-USD","base_currency":"BTC","quote_currency":"USD","status":"online"},{"id":"FOO-USD","base_currency":"FOO","quote_currency":"USD","status":"delisted"},{"id":"BAR-USD","base_currency":"BAR","quote_currency":"USD","status":"online","trading_disabled":true}]`))
+	})
+	mux.HandleFunc("/products/BTC-USD", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"BTC-USD","base_currency":"BTC","quote_currency":"USD","status":"online"}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+// TestRetrieveSymbols tests the RetrieveSymbols function.
+func TestRetrieveSymbols(t *testing.T) {
+	server := newCoinbaseStandIn()
+	defer server.Close()
+	clc := NewCoinbaseListingsChecker()
+	clc.SetApiEndpoint(server.URL)
+
+	symbols, err := clc.RetrieveSymbols()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(symbols) != 1 || symbols[0] != "BTC-USD" { // NOTE: The delisted FOO-USD and disabled BAR-USD products are excluded.
+		t.Errorf("Expected %v, got %v", []string{"BTC-USD"}, symbols)
+	}
+}
+
+// TestRetrieveSymbolInfo tests the RetrieveSymbolInfo function.
+func TestRetrieveSymbolInfo(t *testing.T) {
+	server := newCoinbaseStandIn()
+	defer server.Close()
+	clc := NewCoinbaseListingsChecker()
+	clc.SetApiEndpoint(server.URL)
+
+	info, err := clc.RetrieveSymbolInfo("BTC-USD")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.Exchange != "Coinbase" || info.BaseAsset != "BTC" || info.QuoteAsset != "USD" || info.Status != "online" {
+		t.Errorf("Unexpected symbol info: %+v", info)
+	}
+
+	// Check that unknown products return an error.
+	_, err = clc.RetrieveSymbolInfo("BAR-USD")
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
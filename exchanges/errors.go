@@ -0,0 +1,138 @@
+package exchanges
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Error classes.
+const (
+	ERROR_TRANSIENT    = "transient"    // Temporary failure (e.g. network error or 5xx response) that can be retried.
+	ERROR_RATE_LIMITED = "rate_limited" // Request rate limit exceeded (e.g. 429 response).
+	ERROR_BANNED       = "banned"       // IP address banned by the exchange (e.g. Binance 418 response).
+	ERROR_FATAL        = "fatal"        // Failure that is not resolved by retrying (e.g. invalid request).
+)
+
+// Default retry backoff settings.
+const (
+	DEFAULT_MIN_RETRY_BACKOFF = 1 * time.Second
+	DEFAULT_MAX_RETRY_BACKOFF = 5 * time.Minute
+)
+
+// Error represents a classified exchange error.
+type Error struct {
+	Class      string
+	RetryAfter time.Duration // Time the exchange asked to wait before the next request (zero if unknown).
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns a new classified exchange error.
+func NewError(class string, err error) *Error {
+	return &Error{Class: class, Err: err}
+}
+
+// ClassifyStatusCode returns the error class of a unexpected HTTP status code.
+func ClassifyStatusCode(statusCode int) string {
+	switch {
+	case statusCode == 429:
+		return ERROR_RATE_LIMITED
+	case statusCode == 418:
+		return ERROR_BANNED
+	case statusCode == 408 || statusCode >= 500:
+		return ERROR_TRANSIENT
+	default:
+		return ERROR_FATAL
+	}
+}
+
+// StatusError returns a classified exchange error for a unexpected HTTP status code.
+func StatusError(statusCode int, name string) *Error {
+	return NewError(ClassifyStatusCode(statusCode), fmt.Errorf("%s API endpoint returned status code %d", name, statusCode))
+}
+
+// ParseRetryAfter parses the seconds or HTTP date of a 'Retry-After' header and returns the time to wait (zero if invalid).
+func ParseRetryAfter(retryAfter string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// ErrorClass returns the class of a error.
+// NOTE: Unclassified errors (e.g. network errors) are treated as transient.
+func ErrorClass(err error) string {
+	var exchangeErr *Error
+	if errors.As(err, &exchangeErr) {
+		return exchangeErr.Class
+	}
+	return ERROR_TRANSIENT
+}
+
+// RetryPolicy is a class that returns the time to wait before retrying a failed exchange request based on the error class.
+// NOTE: A single transient error is retried immediately while consecutive failures are retried with exponential backoff.
+type RetryPolicy struct {
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	failures   int
+}
+
+// NewRetryPolicy creates a new RetryPolicy.
+func NewRetryPolicy(minBackoff time.Duration, maxBackoff time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// exponentialBackoff returns the minimum backoff doubled n times and capped at the maximum backoff.
+func (rp *RetryPolicy) exponentialBackoff(n int) time.Duration {
+	backoff := rp.minBackoff
+	for i := 0; i < n && backoff < rp.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > rp.maxBackoff {
+		backoff = rp.maxBackoff
+	}
+	return backoff
+}
+
+// Failure registers a failed request and returns the time to wait before the next request.
+// NOTE: The wait time requested by the exchange takes precedence.
+func (rp *RetryPolicy) Failure(err error) time.Duration {
+	rp.failures++
+	var exchangeErr *Error
+	if errors.As(err, &exchangeErr) && exchangeErr.RetryAfter > 0 {
+		return exchangeErr.RetryAfter
+	}
+
+	switch ErrorClass(err) {
+	case ERROR_RATE_LIMITED:
+		return rp.exponentialBackoff(rp.failures - 1)
+	case ERROR_BANNED, ERROR_FATAL:
+		return rp.maxBackoff
+	default:
+		if rp.failures == 1 {
+			return 0
+		}
+		return rp.exponentialBackoff(rp.failures - 2)
+	}
+}
+
+// Success registers a successful request.
+func (rp *RetryPolicy) Success() {
+	rp.failures = 0
+}
@@ -0,0 +1,51 @@
+// Description: Tests for the exchange errors.
+
+package exchanges
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestErrorClass tests that the ErrorClass function returns the class of wrapped errors and treats unclassified errors as transient.
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{errors.New("connection reset"), ERROR_TRANSIENT},
+		{StatusError(503, "Test"), ERROR_TRANSIENT},
+		{StatusError(429, "Test"), ERROR_RATE_LIMITED},
+		{fmt.Errorf("catalog 48: %w", StatusError(418, "Test")), ERROR_BANNED},
+		{StatusError(400, "Test"), ERROR_FATAL},
+	}
+	for _, test := range tests {
+		if class := ErrorClass(test.err); class != test.class {
+			t.Errorf("Expected %s, got %s", test.class, class)
+		}
+	}
+}
+
+// TestRetryPolicy tests that the RetryPolicy backs off based on the error class.
+func TestRetryPolicy(t *testing.T) {
+	rp := NewRetryPolicy(time.Second, 10*time.Second)
+	transientErr := errors.New("connection reset")
+	for _, expected := range []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second} {
+		if backoff := rp.Failure(transientErr); backoff != expected {
+			t.Errorf("Expected %v, got %v", expected, backoff)
+		}
+	}
+
+	rp.Success()
+	if backoff := rp.Failure(StatusError(429, "Test")); backoff != time.Second {
+		t.Errorf("Expected %v, got %v", time.Second, backoff)
+	}
+	if backoff := rp.Failure(&Error{Class: ERROR_RATE_LIMITED, RetryAfter: 3 * time.Second, Err: transientErr}); backoff != 3*time.Second {
+		t.Errorf("Expected %v, got %v", 3*time.Second, backoff)
+	}
+	if backoff := rp.Failure(StatusError(418, "Test")); backoff != 10*time.Second {
+		t.Errorf("Expected %v, got %v", 10*time.Second, backoff)
+	}
+}
@@ -1,3 +1,187 @@
-// Description: Package exchanges contains general functions for interacting with exchanges.
-// NOTE: Currently only supports Binance.
+// Description: Package exchanges contains the general types and interfaces that are used for interacting with exchanges.
 package exchanges
+
+import "time"
+
+// SymbolInfo represents the exchange agnostic information of a listed symbol.
+type SymbolInfo struct {
+	Exchange     string
+	Symbol       string
+	BaseAsset    string
+	QuoteAsset   string
+	Status       string
+	URL          string
+	ContractType string    // Futures contract type (e.g. PERPETUAL). Empty for spot symbols.
+	OnboardDate  time.Time // Futures contract onboard date.
+	Product      string    // Product the asset was added to (e.g. PRODUCT_CROSS_MARGIN). Empty for spot symbols and futures contracts.
+	BatchSize    int       // Number of assets that were found in the same check (zero if unknown).
+}
+
+// IsFuturesContract returns whether the symbol is a futures contract.
+func (si SymbolInfo) IsFuturesContract() bool {
+	return si.ContractType != ""
+}
+
+// IsProduct returns whether the symbol is a asset that was added to a exchange product (e.g. margin or earn).
+func (si SymbolInfo) IsProduct() bool {
+	return si.Product != ""
+}
+
+// Exchange products.
+const (
+	PRODUCT_CROSS_MARGIN    = "cross_margin"
+	PRODUCT_ISOLATED_MARGIN = "isolated_margin"
+	PRODUCT_SIMPLE_EARN     = "simple_earn"
+	PRODUCT_LAUNCHPOOL      = "launchpool"
+)
+
+// Announcement represents a exchange announcement.
+type Announcement struct {
+	Exchange    string
+	ID          int64
+	Code        string
+	Title       string
+	URL         string
+	CatalogID   int64
+	CatalogName string
+	PublishDate time.Time
+	Kind        string   // Announcement kind (e.g. ANNOUNCEMENT_LISTING).
+	Tickers     []string // Ticker symbols mentioned in the title.
+	Pairs       []string // Trading pairs mentioned in the title.
+	Dates       []string // Dates mentioned in the title.
+}
+
+// Announcement kinds.
+const (
+	ANNOUNCEMENT_LISTING     = "listing"
+	ANNOUNCEMENT_DELISTING   = "delisting"
+	ANNOUNCEMENT_FUTURES     = "futures"
+	ANNOUNCEMENT_LAUNCHPOOL  = "launchpool"
+	ANNOUNCEMENT_MARGIN      = "margin"
+	ANNOUNCEMENT_MAINTENANCE = "maintenance"
+	ANNOUNCEMENT_OTHER       = "other"
+)
+
+// Symbol trading statuses.
+const (
+	STATUS_PRE_TRADING = "PRE_TRADING"
+	STATUS_TRADING     = "TRADING"
+	STATUS_BREAK       = "BREAK"
+)
+
+// StatusTransition represents a change in the trading status of a symbol.
+// NOTE: OldStatus is empty when the symbol was not seen before.
+type StatusTransition struct {
+	Exchange  string
+	Symbol    string
+	OldStatus string
+	NewStatus string
+	Time      time.Time
+	URL       string
+}
+
+// Trade represents a closed position that was opened on a new listing.
+type Trade struct {
+	Exchange   string
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+	Quantity   float64 // Sold quantity.
+	EntryPrice float64 // Average entry fill price.
+	ExitPrice  float64 // Average exit fill price.
+	Fees       float64 // Entry and exit fees in the quote asset.
+	PnL        float64 // Profit and loss in the quote asset after fees.
+	ExitReason string  // Reason the position was closed (e.g. EXIT_TAKE_PROFIT).
+	EntryTime  time.Time
+	ExitTime   time.Time
+	URL        string
+	Simulated  bool // Whether the orders were simulated (i.e. paper trading).
+}
+
+// PnLPercentage returns the profit and loss as a percentage of the entry notional.
+func (t Trade) PnLPercentage() float64 {
+	entryNotional := t.EntryPrice * t.Quantity
+	if entryNotional == 0 {
+		return 0
+	}
+	return t.PnL / entryNotional * 100
+}
+
+// Trade exit reasons.
+const (
+	EXIT_TAKE_PROFIT   = "take_profit"
+	EXIT_STOP_LOSS     = "stop_loss"
+	EXIT_TRAILING_STOP = "trailing_stop"
+	EXIT_MAX_HOLD_TIME = "max_hold_time"
+	EXIT_OCO_CANCELED  = "oco_canceled"
+	// NOTE: EXIT_MANUAL_INTERVENTION is the reason of a alert for a position that could not be closed (its quantity is the open quantity).
+	EXIT_MANUAL_INTERVENTION = "manual_intervention_required"
+)
+
+// ListingSource is the interface that needs to be implemented by exchanges that can be checked for new listings or de-listings.
+type ListingSource interface {
+	// Name returns the name of the exchange.
+	Name() string
+	// RetrieveSymbols retrieves the symbols that are currently listed on the exchange.
+	RetrieveSymbols() ([]string, error)
+	// RetrieveSymbolInfo retrieves information about a given symbol.
+	RetrieveSymbolInfo(symbol string) (SymbolInfo, error)
+}
+
+// ListingStreamSource is the interface that can be implemented by listing sources that can stream the symbols that are being traded.
+type ListingStreamSource interface {
+	ListingSource
+	// StreamSymbols connects to the exchange stream and calls the handler with the symbols found in each stream event.
+	// The returned channel is closed when the stream is disconnected.
+	StreamSymbols(handler func(symbols []string), errHandler func(err error)) (doneC chan struct{}, err error)
+}
+
+// StatusSource is the interface that needs to be implemented by exchanges that can be checked for symbol status transitions.
+type StatusSource interface {
+	// Name returns the name of the exchange.
+	Name() string
+	// RetrieveSymbolStatuses retrieves the trading status of all symbols on the exchange.
+	RetrieveSymbolStatuses() (map[string]string, error)
+	// SymbolURL returns the trading URL of a given symbol.
+	SymbolURL(symbol string) string
+}
+
+// AnnouncementSource is the interface that needs to be implemented by exchanges that can be checked for new announcements.
+type AnnouncementSource interface {
+	// Name returns the name of the exchange.
+	Name() string
+	// RetrieveAnnouncements retrieves the latest announcements of the exchange.
+	RetrieveAnnouncements() ([]Announcement, error)
+}
+
+// CatalogAnnouncementSource is the interface that can be implemented by announcement sources that publish their announcements in multiple catalogs.
+// The catalogs of these sources are checked separately so that a failing catalog does not affect the others.
+type CatalogAnnouncementSource interface {
+	AnnouncementSource
+	// Catalogs returns the IDs of the catalogs that are checked.
+	Catalogs() []int64
+	// RetrieveCatalogAnnouncements retrieves the latest announcements of a given catalog.
+	RetrieveCatalogAnnouncements(catalogID int64) ([]Announcement, error)
+}
+
+// LegacyCatalogSource is the interface that can be implemented by catalog announcement sources that checked a single catalog before their
+// catalogs were checked separately.
+type LegacyCatalogSource interface {
+	// LegacyCatalog returns the ID of the catalog whose announcements are stored under the source name like before.
+	LegacyCatalog() int64
+}
+
+// AdaptiveRateSource is the interface that is implemented by sources that lower the request rate of the checkers when their rate limit is almost used up.
+type AdaptiveRateSource interface {
+	// AdaptRate returns the request rate that keeps the rate limit usage within budget given the configured max rate.
+	AdaptRate(maxRate float64) float64
+}
+
+// AdaptRate returns the adapted request rate of a source or the max rate if the source does not implement the AdaptiveRateSource interface.
+func AdaptRate(source interface{}, maxRate float64) float64 {
+	adaptiveSource, ok := source.(AdaptiveRateSource)
+	if !ok {
+		return maxRate
+	}
+	return adaptiveSource.AdaptRate(maxRate)
+}
@@ -0,0 +1,322 @@
+// Description: Package listingsChecker contains a class that when started periodically checks a exchange for new listings or de-listings and posts a message in set message channels.
+package listingsChecker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+	"golang.org/x/time/rate"
+)
+
+// ListingsChecker is a class that when started checks a exchange for new listings or de-listings and posts a message in set message channels.
+type ListingsChecker struct {
+	Source                exchanges.ListingSource
+	StateStore            store.Store
+	Notifier              messaging.Notifier
+	oldAssets             []string
+	oldAssetsMutex        sync.Mutex
+	streamedAssets        map[string]struct{}
+	streamSynced          bool // Whether the baseline was re-synced since the listing stream (re)connected (see resync).
+	retryPolicy           *exchanges.RetryPolicy
+	targetRate            float64
+	targetRateMutex       sync.Mutex
+	lastAssetsWarningTime time.Time
+}
+
+// Stream reconnect backoff settings.
+const (
+	MIN_RECONNECT_BACKOFF = 1 * time.Second
+	MAX_RECONNECT_BACKOFF = 2 * time.Minute
+)
+
+// NewListingsChecker creates a new ListingsChecker for a given listing source.
+func NewListingsChecker(source exchanges.ListingSource, stateStore store.Store, notifier messaging.Notifier) *ListingsChecker {
+	return &ListingsChecker{
+		Source:                source,
+		StateStore:            stateStore,
+		Notifier:              notifier,
+		streamedAssets:        make(map[string]struct{}),
+		retryPolicy:           exchanges.NewRetryPolicy(exchanges.DEFAULT_MIN_RETRY_BACKOFF, exchanges.DEFAULT_MAX_RETRY_BACKOFF),
+		lastAssetsWarningTime: time.Now(),
+	}
+}
+
+// toSet converts a slice of strings to a set.
+func toSet(s []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(s))
+	for _, str := range s {
+		set[str] = struct{}{}
+	}
+
+	return set
+}
+
+// retrieveAssets retrieves a list with the available assets from the listing source.
+// NOTE: Waits the backoff of the error class if failed and throws warning every minute (or directly for non-transient errors).
+func (lc *ListingsChecker) retrieveAssets() (assets []string) {
+	assets, err := lc.Source.RetrieveSymbols()
+	if err != nil {
+		backoff := lc.retryPolicy.Failure(err)
+		errorClass := exchanges.ErrorClass(err)
+		if errorClass != exchanges.ERROR_TRANSIENT || time.Since(lc.lastAssetsWarningTime) > time.Minute { // Only log every minute.
+			log.Printf("WARNING: Error retrieving %s listings (%s, retrying in %v): %v", lc.Source.Name(), errorClass, backoff, err)
+			lc.lastAssetsWarningTime = time.Now()
+		}
+		time.Sleep(backoff)
+		return nil
+	}
+	lc.retryPolicy.Success()
+
+	return assets
+}
+
+// changedListings checks whether assets were listed or de-listed on the exchange.
+func (lc *ListingsChecker) changedListings() (addedAssets []string, removedAssets []string, oldAssets []string) {
+	assets := lc.retrieveAssets()
+
+	// Return if no assets are available.
+	if len(assets) == 0 {
+		return nil, nil, nil
+	}
+
+	lc.oldAssetsMutex.Lock()
+	defer lc.oldAssetsMutex.Unlock()
+	return lc.diffListings(assets)
+}
+
+// diffListings compares the assets that are returned by the REST API with the old assets and updates the old assets.
+// NOTE: The old assets mutex must be locked.
+func (lc *ListingsChecker) diffListings(assets []string) (addedAssets []string, removedAssets []string, oldAssets []string) {
+	// Keep streamed assets that are not yet returned by the REST API.
+	// NOTE: The assets are not compared without a baseline since all assets would be reported as listed.
+	if len(lc.oldAssets) == 0 {
+		lc.oldAssets = assets
+		return nil, nil, nil
+	}
+	assetsSet := toSet(assets)
+	for asset := range lc.streamedAssets {
+		if _, ok := assetsSet[asset]; ok {
+			delete(lc.streamedAssets, asset)
+		} else {
+			assets = append(assets, asset)
+		}
+	}
+
+	// Return changed assets.
+	addedAssets, removedAssets = utils.DiffLists(lc.oldAssets, assets)
+	lc.oldAssets = assets
+	return addedAssets, removedAssets, assets
+}
+
+// streamedListings checks whether the symbols received from a listing stream contain new listings.
+// NOTE: Streams only contain the symbols that are being traded so de-listings are left to the REST polling.
+func (lc *ListingsChecker) streamedListings(symbols []string) (newAssets []string, oldAssets []string) {
+	lc.oldAssetsMutex.Lock()
+	defer lc.oldAssetsMutex.Unlock()
+	if len(lc.oldAssets) == 0 || !lc.streamSynced { // NOTE: All symbols would be reported as listed without a (re-synced) baseline.
+		return nil, nil
+	}
+	oldAssetsSet := toSet(lc.oldAssets)
+	for _, symbol := range symbols {
+		if _, ok := oldAssetsSet[symbol]; !ok {
+			newAssets = append(newAssets, symbol)
+			oldAssetsSet[symbol] = struct{}{}
+			lc.oldAssets = append(lc.oldAssets, symbol)
+			lc.streamedAssets[symbol] = struct{}{}
+		}
+	}
+	if len(newAssets) == 0 {
+		return nil, nil
+	}
+
+	return newAssets, append([]string{}, lc.oldAssets...)
+}
+
+// Post messages in Telegram and Discord if new listings or de-listings are found.
+func (lc *ListingsChecker) postMessages(removed bool, changedAssets []string, oldAssets []string) {
+	for _, asset := range changedAssets {
+		// Log new listing or de-listing.
+		assetInfo := exchanges.SymbolInfo{Exchange: lc.Source.Name(), Symbol: asset}
+		if removed {
+			log.Printf("%s de-listing found: %v", lc.Source.Name(), asset)
+		} else {
+			log.Printf("%s new listing found: %v", lc.Source.Name(), asset)
+
+			// Retrieve symbol info.
+			info, err := lc.Source.RetrieveSymbolInfo(asset)
+			if err != nil {
+				log.Printf("WARNING: Error retrieving %s symbol info for '%s': %v", lc.Source.Name(), asset, err)
+			} else {
+				assetInfo = info
+			}
+		}
+		assetInfo.BatchSize = len(changedAssets)
+
+		// Post messages.
+		lc.Notifier.NotifyAsset(removed, assetInfo)
+
+		lc.storeOldListings(oldAssets)
+	}
+}
+
+// storeOldListings stores the old listed assets in the state store.
+func (lc *ListingsChecker) storeOldListings(oldAssets []string) {
+	err := lc.StateStore.StoreItems(store.LISTINGS, lc.Source.Name(), oldAssets)
+	if err != nil {
+		log.Printf("WARNING: Error storing %s listings: %v", lc.Source.Name(), err)
+	}
+}
+
+// loadOldListings loads the (old) stored listings or retrieves them from the exchange if none are stored.
+// NOTE: Retries until the exchange returns the listings so that the checking only starts with a baseline.
+func (lc *ListingsChecker) loadOldListings() {
+	oldAssets, err := lc.StateStore.RetrieveItems(store.LISTINGS, lc.Source.Name())
+	if err != nil {
+		log.Fatalf("Error retrieving old %s listings: %v", lc.Source.Name(), err)
+	}
+	log.Printf("Number of old listed %s assets: %d", lc.Source.Name(), len(oldAssets))
+	if len(oldAssets) == 0 { // Get from the exchange if no old listings are stored.
+		for len(oldAssets) == 0 {
+			oldAssets = lc.retrieveAssets() // NOTE: Waits the retry backoff if failed.
+		}
+		lc.storeOldListings(oldAssets)
+	}
+
+	lc.oldAssetsMutex.Lock()
+	defer lc.oldAssetsMutex.Unlock()
+	lc.oldAssets = oldAssets
+}
+
+// setRate sets the polling rate.
+func (lc *ListingsChecker) setRate(limiter *rate.Limiter, targetRate float64) {
+	lc.targetRateMutex.Lock()
+	lc.targetRate = targetRate
+	lc.targetRateMutex.Unlock()
+	limiter.SetLimit(rate.Limit(lc.adaptedRate()))
+}
+
+// adaptedRate returns the polling rate lowered by the listing source when its rate limit is almost used up.
+func (lc *ListingsChecker) adaptedRate() float64 {
+	lc.targetRateMutex.Lock()
+	defer lc.targetRateMutex.Unlock()
+	return exchanges.AdaptRate(lc.Source, lc.targetRate)
+}
+
+// poll checks the exchange for new listings or de-listings and posts messages.
+// NOTE: The polling rate is lowered when the rate limit of the listing source is almost used up.
+func (lc *ListingsChecker) poll(limiter *rate.Limiter) {
+	for {
+		limiter.SetLimit(rate.Limit(lc.adaptedRate()))
+		limiter.Wait(context.Background()) // NOTE: This is to prevent the exchange from blocking the IP address.
+
+		// Check for new listings or de-listings.
+		addedAssets, removedAssets, oldAssets := lc.changedListings()
+
+		// Post messages.
+		if len(addedAssets) != 0 {
+			go lc.postMessages(false, addedAssets, oldAssets)
+		}
+		if len(removedAssets) != 0 {
+			go lc.postMessages(true, removedAssets, oldAssets)
+		}
+	}
+}
+
+// resync re-syncs the baseline with a full REST snapshot after the listing stream (re)connected and posts the changes that were missed.
+// NOTE: The stream deltas are ignored until the baseline is re-synced so that they are not applied to a baseline that missed the changes
+// while the stream was disconnected.
+func (lc *ListingsChecker) resync() {
+	var assets []string
+	for len(assets) == 0 {
+		assets = lc.retrieveAssets() // NOTE: Waits the retry backoff if failed.
+	}
+
+	lc.oldAssetsMutex.Lock()
+	addedAssets, removedAssets, oldAssets := lc.diffListings(assets)
+	lc.streamSynced = true
+	lc.oldAssetsMutex.Unlock()
+	if len(addedAssets) != 0 {
+		go lc.postMessages(false, addedAssets, oldAssets)
+	}
+	if len(removedAssets) != 0 {
+		go lc.postMessages(true, removedAssets, oldAssets)
+	}
+}
+
+// setStreamSynced sets whether the baseline was re-synced since the listing stream (re)connected.
+func (lc *ListingsChecker) setStreamSynced(synced bool) {
+	lc.oldAssetsMutex.Lock()
+	defer lc.oldAssetsMutex.Unlock()
+	lc.streamSynced = synced
+}
+
+// stream connects to the listing stream of the exchange and reconnects with exponential backoff when the connection is lost.
+// NOTE: The REST polling rate is set to fallbackRate while connected and to maxRate while disconnected. The baseline is re-synced from a REST
+// snapshot after every (re)connect (see resync).
+func (lc *ListingsChecker) stream(source exchanges.ListingStreamSource, limiter *rate.Limiter, maxRate float64, fallbackRate float64) {
+	handler := func(symbols []string) {
+		newAssets, oldAssets := lc.streamedListings(symbols)
+		if len(newAssets) != 0 {
+			go lc.postMessages(false, newAssets, oldAssets)
+		}
+	}
+	errHandler := func(err error) {
+		log.Printf("WARNING: %s listings stream error: %v", source.Name(), err)
+	}
+
+	backoff := MIN_RECONNECT_BACKOFF
+	for {
+		doneC, err := source.StreamSymbols(handler, errHandler)
+		if err != nil {
+			log.Printf("WARNING: Error connecting to %s listings stream (retrying in %v): %v", source.Name(), backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > MAX_RECONNECT_BACKOFF {
+				backoff = MAX_RECONNECT_BACKOFF
+			}
+			continue
+		}
+
+		// Throttle the REST polling while the stream is connected.
+		log.Printf("Connected to %s listings stream.", source.Name())
+		lc.resync()
+		lc.setRate(limiter, fallbackRate)
+		backoff = MIN_RECONNECT_BACKOFF
+		<-doneC
+		lc.setStreamSynced(false)
+		lc.setRate(limiter, maxRate)
+		log.Printf("WARNING: %s listings stream disconnected (reconnecting in %v).", source.Name(), backoff)
+		time.Sleep(backoff)
+	}
+}
+
+// Start starts the ListingsChecker.
+func (lc *ListingsChecker) Start(maxRate float64) {
+	lc.loadOldListings()
+	limiter := rate.NewLimiter(rate.Limit(maxRate), 1)
+	lc.setRate(limiter, maxRate)
+	lc.poll(limiter)
+}
+
+// StartStream starts the ListingsChecker using the listing stream of the exchange.
+// NOTE: REST polling is kept as a fallback at fallbackRate and takes over at maxRate while the stream is disconnected.
+func (lc *ListingsChecker) StartStream(maxRate float64, fallbackRate float64) {
+	source, ok := lc.Source.(exchanges.ListingStreamSource)
+	if !ok {
+		log.Printf("WARNING: %s does not support listing streams, falling back to REST polling.", lc.Source.Name())
+		lc.Start(maxRate)
+		return
+	}
+
+	lc.loadOldListings()
+	limiter := rate.NewLimiter(rate.Limit(maxRate), 1)
+	lc.setRate(limiter, maxRate)
+	go lc.stream(source, limiter, maxRate, fallbackRate)
+	lc.poll(limiter)
+}
@@ -0,0 +1,151 @@
+// Description: Tests for the listingsChecker package.
+
+package listingsChecker
+
+import (
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/store/fileStore"
+)
+
+// fakeListingSource is a listing source that returns a fixed list of symbols.
+type fakeListingSource struct {
+	symbols []string
+}
+
+func (fls *fakeListingSource) Name() string {
+	return "Fake"
+}
+
+func (fls *fakeListingSource) RetrieveSymbols() ([]string, error) {
+	return fls.symbols, nil
+}
+
+func (fls *fakeListingSource) RetrieveSymbolInfo(symbol string) (exchanges.SymbolInfo, error) {
+	return exchanges.SymbolInfo{Exchange: fls.Name(), Symbol: symbol}, nil
+}
+
+// fakeNotifier is a notifier that passes the posted listings to a channel.
+type fakeNotifier struct {
+	assets chan exchanges.SymbolInfo
+}
+
+func (fn *fakeNotifier) Name() string {
+	return "Fake"
+}
+
+func (fn *fakeNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	fn.assets <- assetInfo
+	return nil
+}
+
+func (fn *fakeNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return nil
+}
+
+func (fn *fakeNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return nil
+}
+
+func (fn *fakeNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return nil
+}
+
+// TestStreamedListings tests the streamedListings function.
+func TestStreamedListings(t *testing.T) {
+	lc := NewListingsChecker(&fakeListingSource{}, nil, nil)
+	lc.oldAssets, lc.streamSynced = []string{"BTCUSDT", "ETHUSDT"}, true
+
+	newAssets, oldAssets := lc.streamedListings([]string{"ETHUSDT", "FOOUSDT"})
+	if len(newAssets) != 1 || newAssets[0] != "FOOUSDT" {
+		t.Errorf("Expected %v, got %v", []string{"FOOUSDT"}, newAssets)
+	}
+	if len(oldAssets) != 3 {
+		t.Errorf("Expected length of 3, got %d", len(oldAssets))
+	}
+
+	// Check that already seen symbols are not reported again.
+	newAssets, _ = lc.streamedListings([]string{"FOOUSDT"})
+	if len(newAssets) != 0 {
+		t.Errorf("Expected length of 0, got %d", len(newAssets))
+	}
+}
+
+// TestChangedListingsKeepsStreamedAssets tests that the changedListings function does not report streamed assets as removed.
+func TestChangedListingsKeepsStreamedAssets(t *testing.T) {
+	source := &fakeListingSource{symbols: []string{"BTCUSDT", "ETHUSDT"}}
+	lc := NewListingsChecker(source, nil, nil)
+	lc.oldAssets, lc.streamSynced = []string{"BTCUSDT", "ETHUSDT"}, true
+	lc.streamedListings([]string{"FOOUSDT"})
+
+	// REST API does not yet return the streamed asset.
+	addedAssets, removedAssets, _ := lc.changedListings()
+	if len(addedAssets) != 0 || len(removedAssets) != 0 {
+		t.Errorf("Expected no changes, got %v and %v", addedAssets, removedAssets)
+	}
+
+	// REST API returns the streamed asset.
+	source.symbols = []string{"BTCUSDT", "ETHUSDT", "FOOUSDT"}
+	addedAssets, removedAssets, _ = lc.changedListings()
+	if len(addedAssets) != 0 || len(removedAssets) != 0 {
+		t.Errorf("Expected no changes, got %v and %v", addedAssets, removedAssets)
+	}
+	if len(lc.streamedAssets) != 0 {
+		t.Errorf("Expected length of 0, got %d", len(lc.streamedAssets))
+	}
+}
+
+// TestChangedListings tests that the changedListings function reports simultaneous listings and de-listings.
+func TestChangedListings(t *testing.T) {
+	source := &fakeListingSource{symbols: []string{"BTCUSDT", "FOOUSDT"}}
+	lc := NewListingsChecker(source, nil, nil)
+	lc.oldAssets = []string{"BTCUSDT", "ETHUSDT"}
+
+	addedAssets, removedAssets, _ := lc.changedListings()
+	if len(addedAssets) != 1 || addedAssets[0] != "FOOUSDT" {
+		t.Errorf("Expected %v, got %v", []string{"FOOUSDT"}, addedAssets)
+	}
+	if len(removedAssets) != 1 || removedAssets[0] != "ETHUSDT" {
+		t.Errorf("Expected %v, got %v", []string{"ETHUSDT"}, removedAssets)
+	}
+}
+
+// TestNoBaseline tests that no listings are reported when no old listings are available.
+func TestNoBaseline(t *testing.T) {
+	source := &fakeListingSource{symbols: []string{"BTCUSDT", "ETHUSDT"}}
+	lc := NewListingsChecker(source, nil, nil)
+
+	if newAssets, _ := lc.streamedListings([]string{"BTCUSDT"}); len(newAssets) != 0 {
+		t.Errorf("Expected no streamed listings, got %v", newAssets)
+	}
+	addedAssets, removedAssets, _ := lc.changedListings()
+	if len(addedAssets) != 0 || len(removedAssets) != 0 {
+		t.Errorf("Expected no changes, got %v and %v", addedAssets, removedAssets)
+	}
+	if len(lc.oldAssets) != 2 {
+		t.Errorf("Expected length of 2, got %d", len(lc.oldAssets))
+	}
+}
+
+// TestResync tests that the stream deltas are ignored until the baseline is re-synced with the listings that were missed while disconnected.
+func TestResync(t *testing.T) {
+	source := &fakeListingSource{symbols: []string{"BTCUSDT", "ETHUSDT", "FOOUSDT"}}
+	notifier := &fakeNotifier{assets: make(chan exchanges.SymbolInfo, 1)}
+	lc := NewListingsChecker(source, fileStore.NewFileStore(t.TempDir()), notifier)
+	lc.oldAssets = []string{"BTCUSDT", "ETHUSDT"}
+
+	if newAssets, _ := lc.streamedListings([]string{"FOOUSDT"}); len(newAssets) != 0 {
+		t.Errorf("Expected no streamed listings before the re-sync, got %v", newAssets)
+	}
+	lc.resync()
+	if assetInfo := <-notifier.assets; assetInfo.Symbol != "FOOUSDT" {
+		t.Errorf("Expected %s, got %s", "FOOUSDT", assetInfo.Symbol)
+	}
+
+	// Check that the stream deltas are applied to the re-synced baseline.
+	newAssets, _ := lc.streamedListings([]string{"FOOUSDT", "BARUSDT"})
+	if len(newAssets) != 1 || newAssets[0] != "BARUSDT" {
+		t.Errorf("Expected %v, got %v", []string{"BARUSDT"}, newAssets)
+	}
+}
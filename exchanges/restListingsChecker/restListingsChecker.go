@@ -0,0 +1,244 @@
+// Description: Package restListingsChecker contains a class that retrieves the listings of exchanges that expose their trading pairs through a public REST endpoint. It implements the exchanges.ListingSource interface.
+package restListingsChecker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/valyala/fasthttp"
+)
+
+// RestListingsConfig contains the endpoint and field mapping that is used to retrieve the listings of a exchange.
+type RestListingsConfig struct {
+	Name        string // Name of the exchange.
+	Endpoint    string // URL of the endpoint that returns the trading pairs.
+	SymbolsPath string // Dot separated JSON path to the list (or object) containing the trading pairs.
+	SymbolField string // Field containing the symbol. The object key is used if empty.
+	BaseField   string // Field containing the base asset.
+	QuoteField  string // Field containing the quote asset.
+	StatusField string // Field containing the trading status.
+	URLFormat   string // Trading URL format. Supports the {symbol}, {base} and {quote} placeholders.
+}
+
+// PRESETS contains the built-in configurations for the supported exchanges.
+var PRESETS = map[string]RestListingsConfig{
+	"kraken": {
+		Name:        "Kraken",
+		Endpoint:    "https://api.kraken.com/0/public/AssetPairs",
+		SymbolsPath: "result",
+		SymbolField: "altname",
+		BaseField:   "base",
+		QuoteField:  "quote",
+		StatusField: "status",
+		URLFormat:   "https://pro.kraken.com/app/trade/{symbol}",
+	},
+	"okx": {
+		Name:        "OKX",
+		Endpoint:    "https://www.okx.com/api/v5/public/instruments?instType=SPOT",
+		SymbolsPath: "data",
+		SymbolField: "instId",
+		BaseField:   "baseCcy",
+		QuoteField:  "quoteCcy",
+		StatusField: "state",
+		URLFormat:   "https://www.okx.com/trade-spot/{symbol}",
+	},
+	"bybit": {
+		Name:        "Bybit",
+		Endpoint:    "https://api.bybit.com/v5/market/instruments-info?category=spot",
+		SymbolsPath: "result.list",
+		SymbolField: "symbol",
+		BaseField:   "baseCoin",
+		QuoteField:  "quoteCoin",
+		StatusField: "status",
+		URLFormat:   "https://www.bybit.com/en/trade/spot/{base}/{quote}",
+	},
+	"kucoin": {
+		Name:        "KuCoin",
+		Endpoint:    "https://api.kucoin.com/api/v2/symbols",
+		SymbolsPath: "data",
+		SymbolField: "symbol",
+		BaseField:   "baseCurrency",
+		QuoteField:  "quoteCurrency",
+		StatusField: "enableTrading",
+		URLFormat:   "https://www.kucoin.com/trade/{symbol}",
+	},
+}
+
+// RestListingsChecker is a class that retrieves the listings of a exchange using a generic REST endpoint configuration.
+type RestListingsChecker struct {
+	config      RestListingsConfig
+	symbolInfos map[string]exchanges.SymbolInfo
+	mu          sync.RWMutex
+}
+
+// NewRestListingsChecker creates a new RestListingsChecker for a given configuration.
+func NewRestListingsChecker(config RestListingsConfig) *RestListingsChecker {
+	return &RestListingsChecker{
+		config:      config,
+		symbolInfos: make(map[string]exchanges.SymbolInfo),
+	}
+}
+
+// NewRestListingsCheckerFromPreset creates a new RestListingsChecker from a built-in preset (e.g. 'kraken').
+func NewRestListingsCheckerFromPreset(preset string) (*RestListingsChecker, error) {
+	config, ok := PRESETS[strings.ToLower(preset)]
+	if !ok {
+		return nil, fmt.Errorf("unknown REST listings preset '%s'", preset)
+	}
+
+	return NewRestListingsChecker(config), nil
+}
+
+// Name returns the name of the exchange.
+func (rlc *RestListingsChecker) Name() string {
+	return rlc.config.Name
+}
+
+// fieldString returns a field of a JSON object as a string.
+func fieldString(object map[string]interface{}, field string) string {
+	value, ok := object[field]
+	if !ok || value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	return fmt.Sprint(value)
+}
+
+// lookupPath returns the JSON value found at a dot separated path.
+func lookupPath(data interface{}, jsonPath string) (interface{}, error) {
+	if jsonPath == "" {
+		return data, nil
+	}
+
+	for _, key := range strings.Split(jsonPath, ".") {
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("JSON path '%s' not found", jsonPath)
+		}
+		data, ok = object[key]
+		if !ok {
+			return nil, fmt.Errorf("JSON path '%s' not found", jsonPath)
+		}
+	}
+
+	return data, nil
+}
+
+// parseSymbolInfos parses the trading pairs found in a endpoint response body.
+func (rlc *RestListingsChecker) parseSymbolInfos(body []byte) (symbolInfos []exchanges.SymbolInfo, err error) {
+	var data interface{}
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+	symbolsData, err := lookupPath(data, rlc.config.SymbolsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect trading pair objects.
+	// NOTE: Some exchanges (e.g. Kraken) return a object keyed by symbol instead of a list.
+	var keys []string
+	var objects []map[string]interface{}
+	switch symbols := symbolsData.(type) {
+	case []interface{}:
+		for _, symbol := range symbols {
+			if object, ok := symbol.(map[string]interface{}); ok {
+				keys = append(keys, "")
+				objects = append(objects, object)
+			}
+		}
+	case map[string]interface{}:
+		for key := range symbols {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if object, ok := symbols[key].(map[string]interface{}); ok {
+				objects = append(objects, object)
+			} else {
+				objects = append(objects, map[string]interface{}{})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("JSON path '%s' does not contain a list or object", rlc.config.SymbolsPath)
+	}
+
+	// Map trading pair objects to symbol information.
+	for i, object := range objects {
+		symbol := keys[i]
+		if rlc.config.SymbolField != "" {
+			symbol = fieldString(object, rlc.config.SymbolField)
+		}
+		if symbol == "" {
+			continue
+		}
+		base := fieldString(object, rlc.config.BaseField)
+		quote := fieldString(object, rlc.config.QuoteField)
+		symbolInfos = append(symbolInfos, exchanges.SymbolInfo{
+			Exchange:   rlc.config.Name,
+			Symbol:     symbol,
+			BaseAsset:  base,
+			QuoteAsset: quote,
+			Status:     fieldString(object, rlc.config.StatusField),
+			URL:        strings.NewReplacer("{symbol}", symbol, "{base}", base, "{quote}", quote).Replace(rlc.config.URLFormat),
+		})
+	}
+
+	return symbolInfos, nil
+}
+
+// RetrieveSymbols retrieves a list with the available trading pairs from the exchange.
+func (rlc *RestListingsChecker) RetrieveSymbols() (symbols []string, err error) {
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	request.SetRequestURI(rlc.config.Endpoint)
+	request.Header.SetMethod("GET")
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.SetUserAgent("crypto-listings-sniper")
+	err = fasthttp.Do(request, response)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 {
+		return nil, exchanges.StatusError(response.StatusCode(), rlc.config.Name)
+	}
+
+	// Parse and cache trading pairs.
+	symbolInfos, err := rlc.parseSymbolInfos(response.Body())
+	if err != nil {
+		return nil, err
+	}
+	symbols = make([]string, len(symbolInfos))
+	rlc.mu.Lock()
+	defer rlc.mu.Unlock()
+	for i, symbolInfo := range symbolInfos {
+		symbols[i] = symbolInfo.Symbol
+		rlc.symbolInfos[symbolInfo.Symbol] = symbolInfo
+	}
+	return symbols, nil
+}
+
+// RetrieveSymbolInfo retrieves the exchange agnostic information about a given trading pair.
+// NOTE: Uses the information retrieved by the last RetrieveSymbols call.
+func (rlc *RestListingsChecker) RetrieveSymbolInfo(symbol string) (exchanges.SymbolInfo, error) {
+	rlc.mu.RLock()
+	defer rlc.mu.RUnlock()
+	symbolInfo, ok := rlc.symbolInfos[symbol]
+	if !ok {
+		return exchanges.SymbolInfo{}, fmt.Errorf("symbol '%s' not found on %s", symbol, rlc.config.Name)
+	}
+
+	return symbolInfo, nil
+}
@@ -0,0 +1,101 @@
+// Description: Tests for the restListingsChecker package.
+
+package restListingsChecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestLookupPath tests the lookupPath function.
+func TestLookupPath(t *testing.T) {
+	data := map[string]interface{}{"result": map[string]interface{}{"list": []interface{}{"BTCUSDT"}}}
+	r, err := lookupPath(data, "result.list")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(r.([]interface{})) != 1 {
+		t.Errorf("Expected length of 1, got %d", len(r.([]interface{})))
+	}
+
+	_, err = lookupPath(data, "result.data")
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestParseSymbolInfosList tests the parseSymbolInfos function for a list response (e.g. Bybit).
+func TestParseSymbolInfosList(t *testing.T) {
+	rlc := NewRestListingsChecker(PRESETS["bybit"])
+	symbolInfos, err := rlc.parseSymbolInfos([]byte(`{"retCode":0,"result":{"category":"spot","list":[{"symbol":"BTCUSDT","baseCoin":"BTC","quoteCoin":"USDT","status":"Trading"}]}}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(symbolInfos) != 1 {
+		t.Fatalf("Expected length of 1, got %d", len(symbolInfos))
+	}
+	if symbolInfos[0].Symbol != "BTCUSDT" || symbolInfos[0].BaseAsset != "BTC" || symbolInfos[0].QuoteAsset != "USDT" || symbolInfos[0].Status != "Trading" {
+		t.Errorf("Unexpected symbol info: %+v", symbolInfos[0])
+	}
+	if symbolInfos[0].URL != "https://www.bybit.com/en/trade/spot/BTC/USDT" {
+		t.Errorf("Expected %s, got %s", "https://www.bybit.com/en/trade/spot/BTC/USDT", symbolInfos[0].URL)
+	}
+}
+
+// TestParseSymbolInfosObject tests the parseSymbolInfos function for a object response (e.g. Kraken).
+func TestParseSymbolInfosObject(t *testing.T) {
+	rlc := NewRestListingsChecker(PRESETS["kraken"])
+	symbolInfos, err := rlc.parseSymbolInfos([]byte(`{"error":[],"result":{"XXBTZUSD":{"altname":"XBTUSD","base":"XXBT","quote":"ZUSD","status":"online"},"XETHZUSD":{"altname":"ETHUSD","base":"XETH","quote":"ZUSD","status":"online"}}}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(symbolInfos) != 2 {
+		t.Fatalf("Expected length of 2, got %d", len(symbolInfos))
+	}
+	if symbolInfos[0].Symbol != "ETHUSD" || symbolInfos[1].Symbol != "XBTUSD" {
+		t.Errorf("Expected %v, got %v", []string{"ETHUSD", "XBTUSD"}, []string{symbolInfos[0].Symbol, symbolInfos[1].Symbol})
+	}
+}
+
+// TestRetrieveSymbols tests the RetrieveSymbols and RetrieveSymbolInfo functions against a local HTTP stand-in.
+func TestRetrieveSymbols(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"200000","data":[{"symbol":"BTC-USDT","baseCurrency":"BTC","quoteCurrency":"USDT","enableTrading":true}]}`))
+	}))
+	defer server.Close()
+	config := PRESETS["kucoin"]
+	config.Endpoint = server.URL
+	rlc := NewRestListingsChecker(config)
+
+	symbols, err := rlc.RetrieveSymbols()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(symbols) != 1 || symbols[0] != "BTC-USDT" {
+		t.Errorf("Expected %v, got %v", []string{"BTC-USDT"}, symbols)
+	}
+	symbolInfo, err := rlc.RetrieveSymbolInfo("BTC-USDT")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if symbolInfo.Exchange != "KuCoin" || symbolInfo.Status != "true" {
+		t.Errorf("Unexpected symbol info: %+v", symbolInfo)
+	}
+	_, err = rlc.RetrieveSymbolInfo("ETH-USDT")
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestNewRestListingsCheckerFromPreset tests the NewRestListingsCheckerFromPreset function.
+func TestNewRestListingsCheckerFromPreset(t *testing.T) {
+	for _, preset := range []string{"kraken", "OKX", "bybit", "kucoin"} {
+		if _, err := NewRestListingsCheckerFromPreset(preset); err != nil {
+			t.Errorf("Expected no error for preset '%s', got %v", preset, err)
+		}
+	}
+	if _, err := NewRestListingsCheckerFromPreset("unknown"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
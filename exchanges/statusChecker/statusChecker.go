@@ -0,0 +1,128 @@
+// Description: Package statusChecker contains a class that when started periodically checks a exchange for symbol trading status transitions and posts a message in set message channels.
+package statusChecker
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"golang.org/x/time/rate"
+)
+
+// TRACKED_TRANSITIONS contains the status transitions that are posted by default.
+// NOTE: A empty old status means that the symbol was not seen before.
+var TRACKED_TRANSITIONS = [][2]string{
+	{"", exchanges.STATUS_PRE_TRADING},
+	{exchanges.STATUS_PRE_TRADING, exchanges.STATUS_TRADING},
+	{exchanges.STATUS_TRADING, exchanges.STATUS_BREAK},
+	{exchanges.STATUS_BREAK, exchanges.STATUS_TRADING},
+}
+
+// StatusChecker is a class that when started checks a exchange for symbol status transitions and posts a message in set message channels.
+type StatusChecker struct {
+	source                  exchanges.StatusSource
+	notifier                messaging.Notifier
+	trackedTransitions      [][2]string
+	retryPolicy             *exchanges.RetryPolicy
+	lastStatusesWarningTime time.Time
+}
+
+// NewStatusChecker creates a new StatusChecker for a given status source.
+func NewStatusChecker(source exchanges.StatusSource, notifier messaging.Notifier) *StatusChecker {
+	return &StatusChecker{
+		source:                  source,
+		notifier:                notifier,
+		trackedTransitions:      TRACKED_TRANSITIONS,
+		retryPolicy:             exchanges.NewRetryPolicy(exchanges.DEFAULT_MIN_RETRY_BACKOFF, exchanges.DEFAULT_MAX_RETRY_BACKOFF),
+		lastStatusesWarningTime: time.Now(),
+	}
+}
+
+// isTracked checks whether a given status transition should be posted.
+func (sc *StatusChecker) isTracked(oldStatus string, newStatus string) bool {
+	for _, transition := range sc.trackedTransitions {
+		if transition[0] == oldStatus && transition[1] == newStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
+// statusTransitions returns the tracked status transitions between two status snapshots.
+func (sc *StatusChecker) statusTransitions(oldStatuses map[string]string, statuses map[string]string, t time.Time) (transitions []exchanges.StatusTransition) {
+	for symbol, status := range statuses {
+		oldStatus := oldStatuses[symbol]
+		if oldStatus == status || !sc.isTracked(oldStatus, status) {
+			continue
+		}
+
+		transitions = append(transitions, exchanges.StatusTransition{
+			Exchange:  sc.source.Name(),
+			Symbol:    symbol,
+			OldStatus: oldStatus,
+			NewStatus: status,
+			Time:      t,
+			URL:       sc.source.SymbolURL(symbol),
+		})
+	}
+
+	// Sort transitions to get a deterministic message order.
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].Symbol < transitions[j].Symbol
+	})
+	return transitions
+}
+
+// retrieveStatuses retrieves the symbol statuses from the status source.
+// NOTE: Waits the backoff of the error class if failed and throws warning every minute (or directly for non-transient errors).
+func (sc *StatusChecker) retrieveStatuses() (statuses map[string]string) {
+	statuses, err := sc.source.RetrieveSymbolStatuses()
+	if err != nil {
+		backoff := sc.retryPolicy.Failure(err)
+		errorClass := exchanges.ErrorClass(err)
+		if errorClass != exchanges.ERROR_TRANSIENT || time.Since(sc.lastStatusesWarningTime) > time.Minute { // Only log every minute.
+			log.Printf("WARNING: Error retrieving %s symbol statuses (%s, retrying in %v): %v", sc.source.Name(), errorClass, backoff, err)
+			sc.lastStatusesWarningTime = time.Now()
+		}
+		time.Sleep(backoff)
+		return nil
+	}
+	sc.retryPolicy.Success()
+
+	return statuses
+}
+
+// Start starts the StatusChecker.
+func (sc *StatusChecker) Start(maxRate float64) {
+	oldStatuses := sc.retrieveStatuses()
+
+	// Check the exchange for status transitions and post messages.
+	limiter := rate.NewLimiter(rate.Limit(maxRate), 1)
+	for {
+		// NOTE: The rate is lowered when the rate limit of the exchange is almost used up.
+		limiter.SetLimit(rate.Limit(exchanges.AdaptRate(sc.source, maxRate)))
+		limiter.Wait(context.Background()) // NOTE: This is to prevent the exchange from blocking the IP address.
+
+		// Check for status transitions.
+		statuses := sc.retrieveStatuses()
+		if len(statuses) == 0 {
+			continue
+		}
+		if len(oldStatuses) == 0 { // NOTE: Prevents posting all symbols if the first request failed.
+			oldStatuses = statuses
+			continue
+		}
+		transitions := sc.statusTransitions(oldStatuses, statuses, time.Now())
+		oldStatuses = statuses
+
+		// Post messages.
+		for _, transition := range transitions {
+			log.Printf("%s status transition found: %s (%s -> %s)", transition.Exchange, transition.Symbol, transition.OldStatus, transition.NewStatus)
+			sc.notifier.NotifyStatus(transition)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Description: Tests for the statusChecker package.
+
+package statusChecker
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeStatusSource is a status source that returns a fixed set of statuses.
+type fakeStatusSource struct {
+	statuses map[string]string
+}
+
+func (fss *fakeStatusSource) Name() string {
+	return "Fake"
+}
+
+func (fss *fakeStatusSource) RetrieveSymbolStatuses() (map[string]string, error) {
+	return fss.statuses, nil
+}
+
+func (fss *fakeStatusSource) SymbolURL(symbol string) string {
+	return "https://www.google.com/" + symbol
+}
+
+// TestStatusTransitions tests the statusTransitions function.
+func TestStatusTransitions(t *testing.T) {
+	sc := NewStatusChecker(&fakeStatusSource{}, nil)
+	oldStatuses := map[string]string{"AAAUSDT": "PRE_TRADING", "BBBUSDT": "TRADING", "CCCUSDT": "BREAK", "DDDUSDT": "TRADING", "EEEUSDT": "TRADING"}
+	statuses := map[string]string{"AAAUSDT": "TRADING", "BBBUSDT": "BREAK", "CCCUSDT": "TRADING", "DDDUSDT": "TRADING", "EEEUSDT": "END_OF_DAY", "FFFUSDT": "PRE_TRADING"}
+	tNow := time.Now()
+
+	transitions := sc.statusTransitions(oldStatuses, statuses, tNow)
+	expected := []string{"AAAUSDT", "BBBUSDT", "CCCUSDT", "FFFUSDT"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected length of %d, got %d", len(expected), len(transitions))
+	}
+	for i, transition := range transitions {
+		if transition.Symbol != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], transition.Symbol)
+		}
+		if transition.OldStatus != oldStatuses[transition.Symbol] || transition.NewStatus != statuses[transition.Symbol] {
+			t.Errorf("Unexpected transition: %+v", transition)
+		}
+		if !transition.Time.Equal(tNow) {
+			t.Errorf("Expected %v, got %v", tNow, transition.Time)
+		}
+	}
+}
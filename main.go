@@ -3,12 +3,34 @@ package main
 import (
 	"log"
 	"runtime"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/announcementsChecker"
 	"github.com/rickstaa/crypto-listings-sniper/exchanges/binanceAnnouncementsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/binanceFuturesListingsChecker"
 	"github.com/rickstaa/crypto-listings-sniper/exchanges/binanceListingsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/binanceProductsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/coinbaseListingsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/listingsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/restListingsChecker"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges/statusChecker"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
 	dc "github.com/rickstaa/crypto-listings-sniper/messaging/discord"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/email"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/gotify"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/matrix"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/mattermost"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/ntfy"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/outbox"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/slack"
+	tg "github.com/rickstaa/crypto-listings-sniper/messaging/telegram"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/webhook"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+	"github.com/rickstaa/crypto-listings-sniper/store/fileStore"
+	"github.com/rickstaa/crypto-listings-sniper/store/sqliteStore"
+	"github.com/rickstaa/crypto-listings-sniper/trader"
 	"github.com/rickstaa/crypto-listings-sniper/utils"
 
 	"github.com/adshao/go-binance/v2"
@@ -30,6 +52,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error loading Discord bot: %v", err)
 	}
+	discordBot.ShouldRetryOnRateLimit = false // NOTE: Rate limited messages are retried by the message outbox.
 
 	// Log Telegram bot and channel info.
 	telegramBotInfo, err := telegramBot.GetMe()
@@ -53,17 +76,169 @@ func main() {
 
 	// Initialize Binance client.
 	binanceClient := binance.NewClient(envVars.BinanceKey, envVars.BinanceSecret)
-	binanceClient.SetApiEndpoint("https://api4.binance.com")
-	log.Printf("Binance API endpoint: %s", binanceClient.BaseURL)
-	log.Printf("Binance announcement API endpoint: %s", binanceAnnouncementsChecker.GetBinanceAnnouncementsEndpoint())
+	for _, catalogID := range envVars.BinanceCatalogIDs {
+		log.Printf("Binance announcement API endpoint: %s", binanceAnnouncementsChecker.GetBinanceAnnouncementsEndpoint(catalogID))
+	}
+
+	// Initialize state store.
+	var stateStore store.Store = fileStore.NewFileStore("data")
+	if envVars.StateStore == "sqlite" {
+		stateStore, err = sqliteStore.NewSQLiteStore(envVars.SQLiteDBPath)
+		if err != nil {
+			log.Fatalf("Error loading SQLite state store: %v", err)
+		}
+	}
+	log.Printf("State store: %s", envVars.StateStore)
+
+	// Initialize notifiers.
+	sinks := messaging.NewDispatcher()
+	if envVars.EnableTelegramMessage {
+		telegramNotifier := tg.NewTelegramNotifier(telegramBot, envVars.TelegramChatID)
+		telegramNotifier.SetRoutes(envVars.TelegramRoutes)
+		sinks.Register(telegramNotifier)
+	}
+	if envVars.EnableDiscordMessages {
+		discordNotifier := dc.NewDiscordNotifier(discordBot, envVars.DiscordChannelIDs)
+		discordNotifier.SetRoutes(envVars.DiscordRoutes)
+		sinks.Register(discordNotifier)
+	}
+	if envVars.SlackWebhookURL != "" {
+		sinks.Register(slack.NewSlackWebhookNotifier(envVars.SlackWebhookURL))
+	}
+	if envVars.SlackBotToken != "" {
+		sinks.Register(slack.NewSlackBotNotifier(envVars.SlackBotToken, envVars.SlackChannelIDs))
+	}
+	if len(envVars.WebhookURLs) != 0 {
+		sinks.Register(webhook.NewWebhookNotifier(envVars.WebhookURLs, envVars.WebhookSecret, envVars.WebhookDeadLetterPath))
+	}
+	if envVars.MatrixHomeserverURL != "" {
+		sinks.Register(matrix.NewMatrixNotifier(envVars.MatrixHomeserverURL, envVars.MatrixAccessToken, envVars.MatrixRoomIDs))
+	}
+	if envVars.MattermostWebhookURL != "" {
+		sinks.Register(mattermost.NewMattermostNotifier(envVars.MattermostWebhookURL))
+	}
+	if envVars.NtfyTopic != "" {
+		sinks.Register(ntfy.NewNtfyNotifier(envVars.NtfyServerURL, envVars.NtfyTopic, envVars.NtfyAccessToken))
+	}
+	if envVars.GotifyServerURL != "" {
+		sinks.Register(gotify.NewGotifyNotifier(envVars.GotifyServerURL, envVars.GotifyAppToken))
+	}
+	if envVars.SMTPHost != "" {
+		emailNotifier := email.NewEmailNotifier(envVars.SMTPHost, envVars.SMTPPort, envVars.SMTPUsername, envVars.SMTPPassword, envVars.SMTPFrom, envVars.SMTPTo)
+		err = emailNotifier.SetDigestPath(envVars.SMTPDigestPath)
+		if err != nil {
+			log.Fatalf("Error loading email digest: %v", err)
+		}
+		emailNotifier.SetDigestInterval(envVars.SMTPDigestInterval)
+		sinks.Register(emailNotifier)
+	}
+
+	// Queue the messages in a persistent outbox so that they are retried when a messaging service fails.
+	messageOutbox, err := outbox.NewOutbox(envVars.OutboxPath)
+	if err != nil {
+		log.Fatalf("Error loading message outbox: %v", err)
+	}
+	notifier := messaging.NewDispatcher()
+	for _, sink := range sinks.Notifiers() {
+		notifier.Register(messageOutbox.Wrap(sink))
+	}
+	log.Printf("Message outbox: %s (%d pending)", envVars.OutboxPath, messageOutbox.Pending())
+
+	// Initialize exchange sources.
+	binanceListingsSource, err := binanceListingsChecker.NewBinanceListingsChecker(binanceClient, envVars.BinanceListingsRate)
+	if err != nil {
+		log.Fatalf("Error loading Binance listings checker: %v", err)
+	}
+	err = binanceListingsSource.EndpointPool.SetEndpoints(envVars.BinanceEndpoints, envVars.BinanceSources)
+	if err != nil {
+		log.Fatalf("Error setting Binance API endpoints: %v", err)
+	}
+	binanceListingsSource.EndpointPool.SetWeightBudget(binanceListingsChecker.DEFAULT_WEIGHT_LIMIT, envVars.BinanceWeightBudget)
+	log.Printf("Binance API endpoints: %s", strings.Join(envVars.BinanceEndpoints, ", "))
+	if len(envVars.BinanceSources) != 0 {
+		log.Printf("Binance source addresses: %s", strings.Join(envVars.BinanceSources, ", "))
+	}
+	binanceAnnouncementsSource := binanceAnnouncementsChecker.NewBinanceAnnouncementsChecker(binanceClient, envVars.BinanceCatalogIDs...)
+
+	// Initialize trader.
+	// NOTE: The trader uses its own Binance client since the orders can not be sent to the market data endpoints and should not be retried by the message outbox.
+	binanceListingsNotifier := messaging.Notifier(notifier)
+	if envVars.EnableTrader {
+		binanceTrader := trader.NewTrader(binance.NewClient(envVars.BinanceKey, envVars.BinanceSecret), trader.Config{
+			QuoteAssets: envVars.TraderQuoteAssets,
+			OrderType:   envVars.TraderOrderType,
+			Slippage:    envVars.TraderSlippage,
+			MaxWait:     envVars.TraderMaxWait,
+
+			ExitMode:     envVars.TraderExitMode,
+			TakeProfit:   envVars.TraderTakeProfit,
+			StopLoss:     envVars.TraderStopLoss,
+			TrailingStop: envVars.TraderTrailingStop,
+			MaxHoldTime:  envVars.TraderMaxHoldTime,
+
+			MaxBurst: envVars.TraderMaxBurst,
+
+			PaperTrading: envVars.TraderPaperTrading,
+			PaperFeeRate: envVars.TraderPaperFeeRate,
+		}, notifier)
+		binanceListingsNotifier = messaging.NewDispatcher(notifier, binanceTrader)
+		log.Printf("Trader enabled: %s orders with a slippage cap of %v%% (quote assets: %v)", envVars.TraderOrderType, envVars.TraderSlippage*100, envVars.TraderQuoteAssets)
+		if envVars.TraderPaperTrading {
+			log.Printf("Paper trading enabled: orders are simulated against the live order book with a %v%% fee", envVars.TraderPaperFeeRate*100)
+		}
+		if envVars.TraderExitMode != "" {
+			log.Printf("Trader exits enabled: %s (take profit: %v%%, stop loss: %v%%, trailing stop: %v%%, max hold time: %v)", envVars.TraderExitMode, envVars.TraderTakeProfit*100, envVars.TraderStopLoss*100, envVars.TraderTrailingStop*100, envVars.TraderMaxHoldTime)
+		}
+	}
 
 	// Initialize crypto checkers.
-	binanceListingsChecker := binanceListingsChecker.NewBinanceListingsChecker(binanceClient, telegramBot, envVars.TelegramChatID, envVars.EnableTelegramMessage, discordBot, envVars.DiscordChannelIDs, envVars.EnableDiscordMessages)
-	binanceAnnouncementsChecker := binanceAnnouncementsChecker.NewBinanceAnnouncementsChecker(binanceClient, telegramBot, envVars.TelegramChatID, envVars.EnableTelegramMessage, discordBot, envVars.DiscordChannelIDs, envVars.EnableDiscordMessages)
+	binanceListingsChecker := listingsChecker.NewListingsChecker(binanceListingsSource, stateStore, binanceListingsNotifier)
+	binanceAnnouncementsChecker := announcementsChecker.NewAnnouncementsChecker(binanceAnnouncementsSource, stateStore, notifier)
 
 	// start the checkers.
-	go binanceListingsChecker.Start(envVars.BinanceListingsRate)
+	if envVars.BinanceListingsMode == "websocket" {
+		binance.WebsocketKeepalive = true // NOTE: Detects dropped connections so that the stream can be reconnected.
+		go binanceListingsChecker.StartStream(envVars.BinanceListingsRate, envVars.BinanceFallbackRate)
+	} else {
+		go binanceListingsChecker.Start(envVars.BinanceListingsRate)
+	}
 	go binanceAnnouncementsChecker.Start(envVars.BinanceAnnouncementsRate)
+	if envVars.EnableBinanceStatuses {
+		binanceStatusChecker := statusChecker.NewStatusChecker(binanceListingsSource, notifier)
+		go binanceStatusChecker.Start(envVars.BinanceStatusesRate)
+	}
+	if envVars.EnableCoinbaseListings {
+		coinbaseListingsChecker := listingsChecker.NewListingsChecker(coinbaseListingsChecker.NewCoinbaseListingsChecker(), stateStore, notifier)
+		go coinbaseListingsChecker.Start(envVars.CoinbaseListingsRate)
+	}
+	for _, market := range envVars.BinanceFuturesMarkets {
+		binanceFuturesSource, err := binanceFuturesListingsChecker.NewBinanceFuturesListingsChecker(market, envVars.BinanceKey, envVars.BinanceSecret)
+		if err != nil {
+			log.Fatalf("Error loading Binance futures listings checker: %v", err)
+		}
+		binanceFuturesSource.EndpointPool.SetWeightBudget(binanceFuturesListingsChecker.FUTURES_WEIGHT_LIMIT, envVars.BinanceWeightBudget)
+		binanceFuturesListingsChecker := listingsChecker.NewListingsChecker(binanceFuturesSource, stateStore, notifier)
+		go binanceFuturesListingsChecker.Start(envVars.BinanceFuturesListingsRate)
+	}
+	for _, product := range envVars.BinanceProducts {
+		binanceProductsSource, err := binanceProductsChecker.NewBinanceProductsChecker(product, envVars.BinanceKey, envVars.BinanceSecret)
+		if err != nil {
+			log.Fatalf("Error loading Binance products checker: %v", err)
+		}
+		if binanceProductsSource.EndpointPool != nil {
+			binanceProductsSource.EndpointPool.SetWeightBudget(binanceProductsChecker.SAPI_WEIGHT_LIMIT, envVars.BinanceWeightBudget)
+		}
+		binanceProductsChecker := listingsChecker.NewListingsChecker(binanceProductsSource, stateStore, notifier)
+		go binanceProductsChecker.Start(envVars.BinanceProductsRate)
+	}
+	for _, preset := range envVars.RestListingsExchanges {
+		restListingsSource, err := restListingsChecker.NewRestListingsCheckerFromPreset(strings.TrimSpace(preset))
+		if err != nil {
+			log.Fatalf("Error loading REST listings checker: %v", err)
+		}
+		restListingsChecker := listingsChecker.NewListingsChecker(restListingsSource, stateStore, notifier)
+		go restListingsChecker.Start(envVars.RestListingsRate)
+	}
 
 	runtime.Goexit() // Keep the program running.
 }
@@ -2,12 +2,14 @@
 package discord
 
 import (
+	"errors"
+	"fmt"
 	"log"
 
-	"github.com/adshao/go-binance/v2"
 	"github.com/bwmarrin/discordgo"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
 	"github.com/rickstaa/crypto-listings-sniper/messaging/discord/discordEmbeds"
-	"github.com/rickstaa/crypto-listings-sniper/utils"
 )
 
 // SetupDiscordSlashCommands setups the discord slash commands.
@@ -40,7 +42,7 @@ func SetupDiscordSlashCommands(discordBot *discordgo.Session, discordAppID strin
 				},
 			)
 			if err != nil {
-				log.Fatalf("Error responding to telegram invite slash command: %v", err)
+				log.Printf("WARNING: Error responding to telegram invite slash command: %v", err)
 			}
 		},
 		"github-repo": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -55,7 +57,7 @@ func SetupDiscordSlashCommands(discordBot *discordgo.Session, discordAppID strin
 				},
 			)
 			if err != nil {
-				log.Fatalf("Error responding to github repo slash command: %v", err)
+				log.Printf("WARNING: Error responding to github repo slash command: %v", err)
 			}
 		},
 	}
@@ -63,7 +65,7 @@ func SetupDiscordSlashCommands(discordBot *discordgo.Session, discordAppID strin
 	// Register slash commands and handlers.
 	_, err := discordBot.ApplicationCommandBulkOverwrite(discordAppID, "", applicationCommands)
 	if err != nil {
-		log.Fatalf("Error creating global slash commands: %v", err)
+		log.Printf("WARNING: Error creating global slash commands: %v", err)
 	}
 	discordBot.AddHandler(func(
 		s *discordgo.Session,
@@ -77,25 +79,103 @@ func SetupDiscordSlashCommands(discordBot *discordgo.Session, discordAppID strin
 }
 
 // sendDiscordEmbed sends a Discord embed message to the specified channel.
-func sendDiscordEmbed(discordBot *discordgo.Session, discordChannelID string, embed *discordgo.MessageEmbed) {
+func sendDiscordEmbed(discordBot *discordgo.Session, discordChannelID string, embed *discordgo.MessageEmbed) error {
 	_, err := discordBot.ChannelMessageSendEmbed(discordChannelID, embed)
 	if err != nil {
-		log.Fatalf("Error sending discord embed message: %v", err)
+		err = fmt.Errorf("error sending embed message to channel '%s': %w", discordChannelID, err)
+
+		// Return the rate limit bucket wait time so that the message can be retried later.
+		var rateLimitErr *discordgo.RateLimitError
+		if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil {
+			return &messaging.RetryAfterError{Err: err, RetryAfter: rateLimitErr.RetryAfter}
+		}
+		var restErr *discordgo.RESTError
+		if errors.As(err, &restErr) && restErr.Response != nil {
+			return messaging.StatusError(restErr.Response.StatusCode, err)
+		}
+		return err
 	}
+	return nil
 }
 
-// SendAssetDiscordMessage sends a new/removed asset Discord embed message to a specified channel.
-func SendAssetDiscordMessage(discordBot *discordgo.Session, discordChannelIDs []string, removed bool, asset string, assetInfo binance.Symbol) {
-	messageEmbed := discordEmbeds.AssetEmbed(removed, asset, assetInfo)
-	for _, channelID := range discordChannelIDs {
-		go sendDiscordEmbed(discordBot, channelID, &messageEmbed)
+// DiscordNotifier is a class that posts the checker events in Discord channels. It implements the messaging.Notifier interface.
+type DiscordNotifier struct {
+	discordBot        *discordgo.Session
+	discordChannelIDs []string
+	routes            map[string][]string
+	destinations      []string // Channels to post in (all if empty, see WithDestinations).
+}
+
+// NewDiscordNotifier creates a new DiscordNotifier that posts in the given channels.
+func NewDiscordNotifier(discordBot *discordgo.Session, discordChannelIDs []string) *DiscordNotifier {
+	return &DiscordNotifier{
+		discordBot:        discordBot,
+		discordChannelIDs: discordChannelIDs,
 	}
 }
 
-// Send Announcement Discord message to the specified channel.
-func SendAnnouncementDiscordMessage(discordBot *discordgo.Session, discordChannelIDs []string, announcementCode string, announcementTitle string) {
-	messageEmbed := discordEmbeds.AnnouncementEmbed(utils.CreateBinanceArticleURL(announcementCode, announcementTitle), announcementTitle)
+// SetRoutes sets the channels in which the announcements of each kind (e.g. exchanges.ANNOUNCEMENT_LISTING) are posted.
+// NOTE: Announcements of kinds without a route are posted in the default channels.
+func (dn *DiscordNotifier) SetRoutes(routes map[string][]string) {
+	dn.routes = routes
+}
+
+// WithDestinations returns a copy of the notifier that only posts in the given channels. It implements the messaging.DestinationNotifier interface.
+func (dn *DiscordNotifier) WithDestinations(destinations []string) messaging.Notifier {
+	notifier := *dn
+	notifier.destinations = destinations
+	return &notifier
+}
+
+// sendDiscordEmbeds sends a Discord embed message to the specified channels.
+// NOTE: The errors are returned per channel so that only the failed channels are retried.
+func (dn *DiscordNotifier) sendDiscordEmbeds(discordChannelIDs []string, embed *discordgo.MessageEmbed) error {
+	var errs []error
 	for _, channelID := range discordChannelIDs {
-		go sendDiscordEmbed(discordBot, channelID, &messageEmbed)
+		if !messaging.HasDestination(dn.destinations, channelID) {
+			continue
+		}
+		if err := sendDiscordEmbed(dn.discordBot, channelID, embed); err != nil {
+			errs = append(errs, &messaging.DestinationError{Destination: channelID, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// channelIDs returns the channels in which the announcements of a given kind are posted.
+func (dn *DiscordNotifier) channelIDs(kind string) []string {
+	if channelIDs, ok := dn.routes[kind]; ok {
+		return channelIDs
 	}
+
+	return dn.discordChannelIDs
+}
+
+// Name returns the name of the messaging service.
+func (dn *DiscordNotifier) Name() string {
+	return "Discord"
+}
+
+// NotifyAsset sends a new/removed asset Discord embed message to the default channels.
+func (dn *DiscordNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	messageEmbed := discordEmbeds.AssetEmbed(removed, assetInfo)
+	return dn.sendDiscordEmbeds(dn.discordChannelIDs, &messageEmbed)
+}
+
+// NotifyAnnouncement sends a announcement Discord embed message to the channels of the announcement kind.
+func (dn *DiscordNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	messageEmbed := discordEmbeds.AnnouncementEmbed(announcement)
+	return dn.sendDiscordEmbeds(dn.channelIDs(announcement.Kind), &messageEmbed)
+}
+
+// NotifyStatus sends a symbol status transition Discord embed message to the default channels.
+func (dn *DiscordNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	messageEmbed := discordEmbeds.StatusEmbed(transition)
+	return dn.sendDiscordEmbeds(dn.discordChannelIDs, &messageEmbed)
+}
+
+// NotifyTrade sends a closed trade Discord embed message to the default channels.
+func (dn *DiscordNotifier) NotifyTrade(trade exchanges.Trade) error {
+	messageEmbed := discordEmbeds.TradeEmbed(trade)
+	return dn.sendDiscordEmbeds(dn.discordChannelIDs, &messageEmbed)
 }
@@ -3,9 +3,11 @@ package discordEmbeds
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/adshao/go-binance/v2"
 	"github.com/bwmarrin/discordgo"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 	"github.com/rickstaa/crypto-listings-sniper/utils"
 )
 
@@ -22,35 +24,154 @@ var (
 )
 
 // newAssetMessage returns a new asset embed.
-func newAssetMessage(asset string, symbolInfo binance.Symbol) discordgo.MessageEmbed {
+func newAssetMessage(symbolInfo exchanges.SymbolInfo) discordgo.MessageEmbed {
 	embed := ASSET_EMBED
-	embed.Title = fmt.Sprintf("💎 Binance listed new asset (%s)", asset)
+	embed.Title = fmt.Sprintf("💎 %s listed new asset (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
 	embed.Description = fmt.Sprintf("• **Base Asset:** %s\n", symbolInfo.BaseAsset) +
 		fmt.Sprintf("• **Quota Asset:** %s\n", symbolInfo.QuoteAsset)
-	embed.URL = utils.CreateBinanceURL(asset)
+	if symbolInfo.Status != "" {
+		embed.Description += fmt.Sprintf("• **Status:** %s\n", symbolInfo.Status)
+	}
+	embed.URL = symbolInfo.URL
+	return embed
+}
+
+// newFuturesContractMessage returns a new futures contract embed.
+func newFuturesContractMessage(symbolInfo exchanges.SymbolInfo) discordgo.MessageEmbed {
+	embed := ASSET_EMBED
+	embed.Title = fmt.Sprintf("📈 %s listed new futures contract (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
+	embed.Description = fmt.Sprintf("• **Base Asset:** %s\n", symbolInfo.BaseAsset) +
+		fmt.Sprintf("• **Quota Asset:** %s\n", symbolInfo.QuoteAsset) +
+		fmt.Sprintf("• **Contract Type:** %s\n", symbolInfo.ContractType)
+	if !symbolInfo.OnboardDate.IsZero() {
+		embed.Description += fmt.Sprintf("• **Onboard Date:** %s\n", symbolInfo.OnboardDate.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	if symbolInfo.Status != "" {
+		embed.Description += fmt.Sprintf("• **Status:** %s\n", symbolInfo.Status)
+	}
+	embed.URL = symbolInfo.URL
+	return embed
+}
+
+// newProductAssetMessage returns a new product asset embed.
+func newProductAssetMessage(symbolInfo exchanges.SymbolInfo) discordgo.MessageEmbed {
+	embed := ASSET_EMBED
+	embed.Title = fmt.Sprintf("🏦 %s added new asset (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
+	embed.Description = fmt.Sprintf("• **Base Asset:** %s\n", symbolInfo.BaseAsset)
+	if symbolInfo.QuoteAsset != "" {
+		embed.Description += fmt.Sprintf("• **Quota Asset:** %s\n", symbolInfo.QuoteAsset)
+	}
+	if symbolInfo.Status != "" {
+		embed.Description += fmt.Sprintf("• **Status:** %s\n", symbolInfo.Status)
+	}
+	embed.URL = symbolInfo.URL
 	return embed
 }
 
 // removedAssetMessage return a removed asset embed.
-func removedAssetMessage(asset string) discordgo.MessageEmbed {
+func removedAssetMessage(symbolInfo exchanges.SymbolInfo) discordgo.MessageEmbed {
 	embed := ASSET_EMBED
-	embed.Title = fmt.Sprintf("🗑 Binance removed asset (%s)\n", asset)
+	embed.Title = fmt.Sprintf("🗑 %s removed asset (%s)\n", symbolInfo.Exchange, symbolInfo.Symbol)
 	embed.Image = nil
 	return embed
 }
 
 // AssetEmbed returns a asset Discord embed.
-func AssetEmbed(removed bool, asset string, assetInfo binance.Symbol) discordgo.MessageEmbed {
+func AssetEmbed(removed bool, assetInfo exchanges.SymbolInfo) discordgo.MessageEmbed {
 	if removed {
-		return removedAssetMessage(asset)
+		return removedAssetMessage(assetInfo)
+	}
+	if assetInfo.IsFuturesContract() {
+		return newFuturesContractMessage(assetInfo)
 	}
-	return newAssetMessage(asset, assetInfo)
+	if assetInfo.IsProduct() {
+		return newProductAssetMessage(assetInfo)
+	}
+	return newAssetMessage(assetInfo)
 }
 
 // AnnouncementEmbed returns a new announcement embed.
-func AnnouncementEmbed(url string, title string) discordgo.MessageEmbed {
+// NOTE: The structured fields are only added when they were extracted from the title.
+func AnnouncementEmbed(announcement exchanges.Announcement) discordgo.MessageEmbed {
 	embed := ANNOUNCEMENT_EMBED
-	embed.Title = fmt.Sprintf("📢 %s", title)
-	embed.URL = url
+	embed.Title = fmt.Sprintf("📢 %s", announcement.Title)
+	embed.URL = announcement.URL
+	embed.Fields = nil
+	if announcement.CatalogName != "" {
+		embed.Author = &discordgo.MessageEmbedAuthor{Name: announcement.CatalogName}
+	}
+	if announcement.Kind != "" && announcement.Kind != exchanges.ANNOUNCEMENT_OTHER {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Kind", Value: announcement.Kind, Inline: true})
+	}
+	if len(announcement.Tickers) != 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Tickers", Value: strings.Join(announcement.Tickers, ", "), Inline: true})
+	}
+	if len(announcement.Pairs) != 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Pairs", Value: strings.Join(announcement.Pairs, ", "), Inline: true})
+	}
+	if len(announcement.Dates) != 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Dates", Value: strings.Join(announcement.Dates, ", "), Inline: true})
+	}
+	return embed
+}
+
+// statusTitle returns the title of a symbol status transition embed.
+func statusTitle(transition exchanges.StatusTransition) string {
+	switch {
+	case transition.OldStatus == "" && transition.NewStatus == exchanges.STATUS_PRE_TRADING:
+		return fmt.Sprintf("🔜 %s added pre-trading asset (%s)", transition.Exchange, transition.Symbol)
+	case transition.OldStatus == exchanges.STATUS_PRE_TRADING && transition.NewStatus == exchanges.STATUS_TRADING:
+		return fmt.Sprintf("🚀 %s opened trading (%s)", transition.Exchange, transition.Symbol)
+	case transition.NewStatus == exchanges.STATUS_BREAK:
+		return fmt.Sprintf("⏸ %s halted trading (%s)", transition.Exchange, transition.Symbol)
+	case transition.OldStatus == exchanges.STATUS_BREAK && transition.NewStatus == exchanges.STATUS_TRADING:
+		return fmt.Sprintf("▶️ %s resumed trading (%s)", transition.Exchange, transition.Symbol)
+	default:
+		return fmt.Sprintf("🔄 %s changed trading status (%s)", transition.Exchange, transition.Symbol)
+	}
+}
+
+// StatusEmbed returns a symbol status transition embed.
+func StatusEmbed(transition exchanges.StatusTransition) discordgo.MessageEmbed {
+	oldStatus := transition.OldStatus
+	if oldStatus == "" {
+		oldStatus = "NONE"
+	}
+	embed := ASSET_EMBED
+	embed.Title = statusTitle(transition)
+	embed.Description = fmt.Sprintf("• **Status:** %s → %s\n", oldStatus, transition.NewStatus)
+	embed.Timestamp = transition.Time.UTC().Format(time.RFC3339)
+	embed.URL = transition.URL
+	embed.Image = nil
+	return embed
+}
+
+// tradeTitle returns the title of a closed trade embed.
+// NOTE: Simulated trades are marked as paper positions.
+func tradeTitle(trade exchanges.Trade) string {
+	position := "position"
+	if trade.Simulated {
+		position = "paper position"
+	}
+	if trade.PnL < 0 {
+		return fmt.Sprintf("🔻 %s closed %s with loss (%s)", trade.Exchange, position, trade.Symbol)
+	}
+	return fmt.Sprintf("💰 %s closed %s with profit (%s)", trade.Exchange, position, trade.Symbol)
+}
+
+// TradeEmbed returns a closed trade embed with the fill prices and PnL.
+func TradeEmbed(trade exchanges.Trade) discordgo.MessageEmbed {
+	embed := ASSET_EMBED
+	embed.Title = tradeTitle(trade)
+	embed.Description = fmt.Sprintf("• **Quantity:** %s %s\n", utils.FormatAmount(trade.Quantity), trade.BaseAsset) +
+		fmt.Sprintf("• **Entry Price:** %s %s\n", utils.FormatAmount(trade.EntryPrice), trade.QuoteAsset) +
+		fmt.Sprintf("• **Exit Price:** %s %s\n", utils.FormatAmount(trade.ExitPrice), trade.QuoteAsset) +
+		fmt.Sprintf("• **Fees:** %s %s\n", utils.FormatAmount(trade.Fees), trade.QuoteAsset) +
+		fmt.Sprintf("• **PnL:** %s %s (%+.2f%%)\n", utils.FormatAmount(trade.PnL), trade.QuoteAsset, trade.PnLPercentage()) +
+		fmt.Sprintf("• **Exit Reason:** %s\n", strings.ReplaceAll(trade.ExitReason, "_", " ")) +
+		fmt.Sprintf("• **Holding Time:** %v\n", trade.ExitTime.Sub(trade.EntryTime).Round(time.Second))
+	embed.Timestamp = trade.ExitTime.UTC().Format(time.RFC3339)
+	embed.URL = trade.URL
+	embed.Image = nil
 	return embed
 }
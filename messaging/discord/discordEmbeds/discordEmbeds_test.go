@@ -3,14 +3,16 @@
 package discordEmbeds
 
 import (
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 )
 
 // TestNewAssetMessage tests the newAssetMessage function.
 func TestNewAssetMessage(t *testing.T) {
-	embed := newAssetMessage("BTC", binance.Symbol{BaseAsset: "BTC", QuoteAsset: "USDT"})
+	embed := newAssetMessage(exchanges.SymbolInfo{Exchange: "Binance", Symbol: "BTC", BaseAsset: "BTC", QuoteAsset: "USDT"})
 	if embed.Title != "💎 Binance listed new asset (BTC)" {
 		t.Errorf("Expected %s, got %s", "💎 Binance listed new asset (BTC)", embed.Title)
 	}
@@ -19,9 +21,42 @@ func TestNewAssetMessage(t *testing.T) {
 	}
 }
 
+// TestNewAssetMessageStatus tests the newAssetMessage function with a symbol status.
+func TestNewAssetMessageStatus(t *testing.T) {
+	embed := newAssetMessage(exchanges.SymbolInfo{Exchange: "Coinbase", Symbol: "BTC-USD", BaseAsset: "BTC", QuoteAsset: "USD", Status: "online"})
+	if embed.Title != "💎 Coinbase listed new asset (BTC-USD)" {
+		t.Errorf("Expected %s, got %s", "💎 Coinbase listed new asset (BTC-USD)", embed.Title)
+	}
+	if embed.Description != "• **Base Asset:** BTC\n• **Quota Asset:** USD\n• **Status:** online\n" {
+		t.Errorf("Expected %s, got %s", "• **Base Asset:** BTC\n• **Quota Asset:** USD\n• **Status:** online\n", embed.Description)
+	}
+}
+
+// TestAssetEmbedFuturesContract tests that the AssetEmbed function returns a futures contract embed for futures contracts.
+func TestAssetEmbedFuturesContract(t *testing.T) {
+	embed := AssetEmbed(false, exchanges.SymbolInfo{Exchange: "Binance COIN-M Futures", Symbol: "FOOUSD_PERP", BaseAsset: "FOO", QuoteAsset: "USD", ContractType: "PERPETUAL"})
+	if embed.Title != "📈 Binance COIN-M Futures listed new futures contract (FOOUSD_PERP)" {
+		t.Errorf("Expected %s, got %s", "📈 Binance COIN-M Futures listed new futures contract (FOOUSD_PERP)", embed.Title)
+	}
+	if !strings.Contains(embed.Description, "• **Contract Type:** PERPETUAL\n") {
+		t.Errorf("Expected %s, got %s", "• **Contract Type:** PERPETUAL\n", embed.Description)
+	}
+}
+
+// TestAssetEmbedProduct tests that the AssetEmbed function returns a product asset embed for product assets.
+func TestAssetEmbedProduct(t *testing.T) {
+	embed := AssetEmbed(false, exchanges.SymbolInfo{Exchange: "Binance Isolated Margin", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", Product: exchanges.PRODUCT_ISOLATED_MARGIN})
+	if embed.Title != "🏦 Binance Isolated Margin added new asset (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "🏦 Binance Isolated Margin added new asset (FOOUSDT)", embed.Title)
+	}
+	if !strings.Contains(embed.Description, "• **Quota Asset:** USDT\n") {
+		t.Errorf("Expected %s, got %s", "• **Quota Asset:** USDT\n", embed.Description)
+	}
+}
+
 // TestRemovedAssetMessage tests the removedAssetMessage function.
 func TestRemovedAssetMessage(t *testing.T) {
-	embed := removedAssetMessage("BTC")
+	embed := removedAssetMessage(exchanges.SymbolInfo{Exchange: "Binance", Symbol: "BTC"})
 	if embed.Title != "🗑 Binance removed asset (BTC)\n" {
 		t.Errorf("Expected %s, got %s", "🗑 Binance removed asset (BTC)\n", embed.Title)
 	}
@@ -32,11 +67,64 @@ func TestRemovedAssetMessage(t *testing.T) {
 
 // TestAssetEmbed tests the AssetEmbed function.
 func TestAnnouncementEmbed(t *testing.T) {
-	embed := AnnouncementEmbed("https://www.google.com", "Test")
+	embed := AnnouncementEmbed(exchanges.Announcement{URL: "https://www.google.com", Title: "Test"})
 	if embed.Title != "📢 Test" {
 		t.Errorf("Expected %s, got %s", "📢 Test", embed.Title)
 	}
 	if embed.URL != "https://www.google.com" {
 		t.Errorf("Expected %s, got %s", "https://www.google.com", embed.URL)
 	}
+	if len(embed.Fields) != 0 {
+		t.Errorf("Expected length of 0, got %d", len(embed.Fields))
+	}
+	if embed.Author != nil {
+		t.Errorf("Expected nil, got %v", embed.Author)
+	}
+}
+
+// TestAnnouncementEmbedCatalog tests that the AnnouncementEmbed function tags the embed with the catalog name.
+func TestAnnouncementEmbedCatalog(t *testing.T) {
+	embed := AnnouncementEmbed(exchanges.Announcement{URL: "https://www.google.com", Title: "Test", CatalogName: "Delisting"})
+	if embed.Author == nil || embed.Author.Name != "Delisting" {
+		t.Errorf("Expected %s, got %v", "Delisting", embed.Author)
+	}
+}
+
+// TestAnnouncementEmbedFields tests the AnnouncementEmbed function with structured fields.
+func TestAnnouncementEmbedFields(t *testing.T) {
+	embed := AnnouncementEmbed(exchanges.Announcement{URL: "https://www.google.com", Title: "Test", Kind: "delisting", Tickers: []string{"FOO", "BAR"}, Dates: []string{"2023-05-15"}})
+	if len(embed.Fields) != 3 {
+		t.Fatalf("Expected length of 3, got %d", len(embed.Fields))
+	}
+	if embed.Fields[0].Value != "delisting" || embed.Fields[1].Value != "FOO, BAR" || embed.Fields[2].Value != "2023-05-15" {
+		t.Errorf("Unexpected fields: %v, %v, %v", embed.Fields[0], embed.Fields[1], embed.Fields[2])
+	}
+}
+
+// TestStatusEmbed tests the StatusEmbed function.
+func TestStatusEmbed(t *testing.T) {
+	transition := exchanges.StatusTransition{Exchange: "Binance", Symbol: "FOOUSDT", OldStatus: "TRADING", NewStatus: "BREAK", Time: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC), URL: "https://www.google.com"}
+	embed := StatusEmbed(transition)
+	if embed.Title != "⏸ Binance halted trading (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "⏸ Binance halted trading (FOOUSDT)", embed.Title)
+	}
+	if embed.Description != "• **Status:** TRADING → BREAK\n" {
+		t.Errorf("Expected %s, got %s", "• **Status:** TRADING → BREAK\n", embed.Description)
+	}
+	if embed.Timestamp != "2023-05-01T12:00:00Z" {
+		t.Errorf("Expected %s, got %s", "2023-05-01T12:00:00Z", embed.Timestamp)
+	}
+}
+
+// TestTradeEmbed tests the TradeEmbed function with a losing trade.
+func TestTradeEmbed(t *testing.T) {
+	entryTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	trade := exchanges.Trade{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", Quantity: 10, EntryPrice: 1, ExitPrice: 0.9, Fees: 0.02, PnL: -1.02, ExitReason: exchanges.EXIT_STOP_LOSS, EntryTime: entryTime, ExitTime: entryTime.Add(time.Hour)}
+	embed := TradeEmbed(trade)
+	if embed.Title != "🔻 Binance closed position with loss (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "🔻 Binance closed position with loss (FOOUSDT)", embed.Title)
+	}
+	if !strings.Contains(embed.Description, "• **PnL:** -1.02 USDT (-10.20%)\n• **Exit Reason:** stop loss\n• **Holding Time:** 1h0m0s\n") {
+		t.Errorf("Expected %s, got %s", "-1.02 USDT (-10.20%) PnL", embed.Description)
+	}
 }
@@ -0,0 +1,20 @@
+// Description: Tests for the discord package.
+
+package discord
+
+import (
+	"testing"
+)
+
+// TestChannelIDs tests that the DiscordNotifier routes announcement kinds to their channels.
+func TestChannelIDs(t *testing.T) {
+	dn := NewDiscordNotifier(nil, []string{"1"})
+	dn.SetRoutes(map[string][]string{"delisting": {"2"}})
+
+	if channelIDs := dn.channelIDs("delisting"); len(channelIDs) != 1 || channelIDs[0] != "2" {
+		t.Errorf("Expected %v, got %v", []string{"2"}, channelIDs)
+	}
+	if channelIDs := dn.channelIDs("listing"); len(channelIDs) != 1 || channelIDs[0] != "1" {
+		t.Errorf("Expected %v, got %v", []string{"1"}, channelIDs)
+	}
+}
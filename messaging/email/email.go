@@ -0,0 +1,222 @@
+// Description: The email package contains a notifier that sends the checker events by email, either immediately or batched in a HTML digest.
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/messageFormats"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/telegram/telegramMessages"
+)
+
+// Digest intervals.
+// NOTE: A interval of zero sends each event immediately.
+const (
+	DIGEST_IMMEDIATE = 0
+	DIGEST_HOURLY    = time.Hour
+	DIGEST_DAILY     = 24 * time.Hour
+)
+
+// EmailNotifier is a class that sends the checker events by email. It implements the messaging.Notifier interface.
+type EmailNotifier struct {
+	host           string
+	port           int
+	username       string
+	password       string
+	from           string
+	to             []string
+	digestInterval time.Duration
+	digest         []string
+	digestPath     string
+	digestMutex    sync.Mutex
+}
+
+// NewEmailNotifier creates a new EmailNotifier that sends emails using the given SMTP server.
+// NOTE: Authentication is only used when a username is given.
+func NewEmailNotifier(host string, port int, username string, password string, from string, to []string) *EmailNotifier {
+	return &EmailNotifier{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+// SetDigestInterval batches the events in a HTML digest that is sent every interval.
+// NOTE: A digest that was stored before is sent immediately when the events are no longer batched.
+func (en *EmailNotifier) SetDigestInterval(digestInterval time.Duration) {
+	en.digestInterval = digestInterval
+	if digestInterval <= 0 {
+		go func() {
+			err := en.Flush()
+			if err != nil {
+				log.Printf("WARNING: Error sending email digest: %v", err)
+			}
+		}()
+	} else {
+		go func() {
+			for range time.Tick(digestInterval) {
+				err := en.Flush()
+				if err != nil {
+					log.Printf("WARNING: Error sending email digest: %v", err)
+				}
+			}
+		}()
+	}
+}
+
+// SetDigestPath stores the pending digest in the given file so that the batched events survive a restart and loads the digest that was
+// stored before.
+func (en *EmailNotifier) SetDigestPath(digestPath string) error {
+	en.digestMutex.Lock()
+	defer en.digestMutex.Unlock()
+	en.digestPath = digestPath
+
+	digestJson, err := os.ReadFile(digestPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading email digest file '%s': %w", digestPath, err)
+	}
+	if len(digestJson) != 0 {
+		var digest []string
+		err = json.Unmarshal(digestJson, &digest)
+		if err != nil {
+			return fmt.Errorf("error unmarshalling email digest: %w", err)
+		}
+		en.digest = append(digest, en.digest...)
+	}
+	return nil
+}
+
+// storeDigest writes the pending digest to the digest file.
+// NOTE: Should be called with the digest mutex locked.
+func (en *EmailNotifier) storeDigest() error {
+	if en.digestPath == "" {
+		return nil
+	}
+	digestJson, err := json.Marshal(en.digest)
+	if err != nil {
+		return fmt.Errorf("error marshalling email digest: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(en.digestPath), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("error creating email digest folder: %w", err)
+	}
+	tmpPath := en.digestPath + ".tmp"
+	err = os.WriteFile(tmpPath, digestJson, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing email digest file '%s': %w", tmpPath, err)
+	}
+	return os.Rename(tmpPath, en.digestPath)
+}
+
+// Name returns the name of the messaging service.
+func (en *EmailNotifier) Name() string {
+	return "Email"
+}
+
+// sendEmail sends a HTML email to all recipients.
+func (en *EmailNotifier) sendEmail(subject string, body string) error {
+	var auth smtp.Auth
+	if en.username != "" {
+		auth = smtp.PlainAuth("", en.username, en.password, en.host)
+	}
+
+	message := fmt.Sprintf("From: %s\r\n", en.from) +
+		fmt.Sprintf("To: %s\r\n", strings.Join(en.to, ", ")) +
+		fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)) +
+		fmt.Sprintf("Date: %s\r\n", time.Now().Format(time.RFC1123Z)) +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/html; charset=UTF-8\r\n" +
+		"\r\n" + body
+	err := smtp.SendMail(net.JoinHostPort(en.host, strconv.Itoa(en.port)), auth, en.from, en.to, []byte(message))
+	if err != nil {
+		return fmt.Errorf("error sending email '%s': %w", subject, err)
+	}
+	return nil
+}
+
+// htmlBody converts a Telegram HTML message to a email HTML body.
+func htmlBody(message string) string {
+	return strings.ReplaceAll(strings.TrimRight(message, "\n"), "\n", "<br>")
+}
+
+// send sends a Telegram HTML message immediately or adds it to the digest.
+// NOTE: A message is only acknowledged once it is stored in the digest file (see SetDigestPath).
+func (en *EmailNotifier) send(message string) error {
+	if en.digestInterval <= 0 {
+		return en.sendEmail(messageFormats.Title(message), "<html><body>"+htmlBody(message)+"</body></html>")
+	}
+
+	en.digestMutex.Lock()
+	defer en.digestMutex.Unlock()
+	en.digest = append(en.digest, message)
+	err := en.storeDigest()
+	if err != nil {
+		en.digest = en.digest[:len(en.digest)-1]
+		return err
+	}
+	return nil
+}
+
+// Flush sends the batched events as HTML digest.
+// NOTE: The events are kept in the digest when the digest can not be sent.
+func (en *EmailNotifier) Flush() (err error) {
+	en.digestMutex.Lock()
+	digest := en.digest
+	en.digest = nil
+	en.digestMutex.Unlock()
+	if len(digest) == 0 {
+		return nil
+	}
+	defer func() {
+		en.digestMutex.Lock()
+		defer en.digestMutex.Unlock()
+		if err != nil {
+			en.digest = append(digest, en.digest...)
+		}
+		if storeErr := en.storeDigest(); storeErr != nil {
+			log.Printf("WARNING: Error storing email digest: %v", storeErr)
+		}
+	}()
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("<html><body><h2>Crypto listings digest (%d events)</h2><ul>", len(digest)))
+	for _, message := range digest {
+		body.WriteString("<li>" + htmlBody(message) + "</li>")
+	}
+	body.WriteString("</ul></body></html>")
+	return en.sendEmail(fmt.Sprintf("Crypto listings digest: %d events", len(digest)), body.String())
+}
+
+// NotifyAsset sends a new/removed asset email.
+func (en *EmailNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return en.send(telegramMessages.AssetMessage(removed, assetInfo))
+}
+
+// NotifyAnnouncement sends a announcement email.
+func (en *EmailNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return en.send(telegramMessages.AnnouncementMessage(announcement))
+}
+
+// NotifyStatus sends a symbol status transition email.
+func (en *EmailNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return en.send(telegramMessages.StatusMessage(transition))
+}
+
+// NotifyTrade sends a closed trade email.
+func (en *EmailNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return en.send(telegramMessages.TradeMessage(trade))
+}
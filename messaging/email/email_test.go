@@ -0,0 +1,197 @@
+// Description: Tests for the email package.
+
+package email
+
+import (
+	"bufio"
+	"mime"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// capturedEmail represents a email received by the SMTP capture server.
+type capturedEmail struct {
+	from string
+	to   []string
+	data string
+}
+
+// newSMTPCaptureServer starts a local SMTP server that captures the received emails.
+func newSMTPCaptureServer(t *testing.T) (host string, port int, emails chan capturedEmail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	emails = make(chan capturedEmail, 10)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				write := func(line string) { conn.Write([]byte(line + "\r\n")) }
+				write("220 localhost ESMTP capture")
+				var email capturedEmail
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					command := strings.ToUpper(strings.TrimSpace(line))
+					switch {
+					case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+						write("250 localhost")
+					case strings.HasPrefix(command, "MAIL FROM:"):
+						email.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+						write("250 OK")
+					case strings.HasPrefix(command, "RCPT TO:"):
+						email.to = append(email.to, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+						write("250 OK")
+					case command == "DATA":
+						write("354 End data with <CR><LF>.<CR><LF>")
+						var data strings.Builder
+						for {
+							dataLine, err := reader.ReadString('\n')
+							if err != nil || dataLine == ".\r\n" {
+								break
+							}
+							data.WriteString(dataLine)
+						}
+						email.data = data.String()
+						emails <- email
+						email = capturedEmail{}
+						write("250 OK")
+					case command == "QUIT":
+						write("221 Bye")
+						return
+					default:
+						write("250 OK")
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	host, portString, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ = strconv.Atoi(portString)
+	return host, port, emails
+}
+
+// receive waits for a captured email.
+func receive(t *testing.T, emails chan capturedEmail) capturedEmail {
+	select {
+	case email := <-emails:
+		return email
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a email, got none")
+	}
+	return capturedEmail{}
+}
+
+// TestNotifyImmediate tests that events are sent immediately when no digest interval is set.
+func TestNotifyImmediate(t *testing.T) {
+	host, port, emails := newSMTPCaptureServer(t)
+	en := NewEmailNotifier(host, port, "", "", "sniper@example.com", []string{"a@example.com", "b@example.com"})
+
+	err := en.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	email := receive(t, emails)
+	if email.from != "sniper@example.com" || len(email.to) != 2 {
+		t.Errorf("Unexpected envelope: %s, %v", email.from, email.to)
+	}
+	if !strings.Contains(email.data, "Subject: "+mime.QEncoding.Encode("utf-8", "📢 test")) {
+		t.Errorf("Expected subject in %s", email.data)
+	}
+	if !strings.Contains(email.data, "Content-Type: text/html") || !strings.Contains(email.data, "<a href='https://www.google.com'>test</a>") {
+		t.Errorf("Expected HTML body in %s", email.data)
+	}
+}
+
+// TestNotifyDigest tests that events are batched in a single digest.
+func TestNotifyDigest(t *testing.T) {
+	host, port, emails := newSMTPCaptureServer(t)
+	en := NewEmailNotifier(host, port, "", "", "sniper@example.com", []string{"a@example.com"})
+	en.SetDigestInterval(time.Hour)
+
+	en.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "first"})
+	en.NotifyAsset(false, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT"})
+	select {
+	case <-emails:
+		t.Fatalf("Expected no email before the digest is flushed")
+	default:
+	}
+
+	if err := en.Flush(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	email := receive(t, emails)
+	if !strings.Contains(email.data, "Crypto listings digest: 2 events") {
+		t.Errorf("Expected digest subject in %s", email.data)
+	}
+	if strings.Count(email.data, "<li>") != 2 {
+		t.Errorf("Expected 2 digest entries in %s", email.data)
+	}
+
+	// Flushing a empty digest should not send a email.
+	if err := en.Flush(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestDigestRestart tests that the pending digest is stored so that it is sent after a restart.
+func TestDigestRestart(t *testing.T) {
+	host, port, emails := newSMTPCaptureServer(t)
+	digestPath := filepath.Join(t.TempDir(), "email_digest.json")
+	en := NewEmailNotifier(host, port, "", "", "sniper@example.com", []string{"a@example.com"})
+	if err := en.SetDigestPath(digestPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	en.SetDigestInterval(time.Hour)
+	en.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "first"})
+
+	restartedEn := NewEmailNotifier(host, port, "", "", "sniper@example.com", []string{"a@example.com"})
+	if err := restartedEn.SetDigestPath(digestPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := restartedEn.Flush(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	email := receive(t, emails)
+	if !strings.Contains(email.data, "Crypto listings digest: 1 events") {
+		t.Errorf("Expected digest subject in %s", email.data)
+	}
+}
+
+// TestFlushError tests that the events are kept in the digest when the digest can not be sent.
+func TestFlushError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	host, portString, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portString)
+	listener.Close() // NOTE: Closed so that the SMTP connection is refused.
+	en := NewEmailNotifier(host, port, "", "", "sniper@example.com", []string{"a@example.com"})
+	en.SetDigestInterval(time.Hour)
+	en.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "first"})
+
+	if err := en.Flush(); err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	if len(en.digest) != 1 {
+		t.Errorf("Expected 1 pending event, got %d", len(en.digest))
+	}
+}
@@ -0,0 +1,104 @@
+// Description: The gotify package contains functions for sending Gotify push notifications.
+package gotify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/messageFormats"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/telegram/telegramMessages"
+	"github.com/valyala/fasthttp"
+)
+
+// DEFAULT_PRIORITY is the priority of the Gotify push notifications.
+const DEFAULT_PRIORITY = 5
+
+// GotifyMessage represents a Gotify message.
+type GotifyMessage struct {
+	Title    string                 `json:"title"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+// GotifyNotifier is a class that sends the checker events as Gotify push notifications. It implements the messaging.Notifier interface.
+type GotifyNotifier struct {
+	serverURL string
+	appToken  string
+}
+
+// NewGotifyNotifier creates a new GotifyNotifier that sends to the given server using a application token.
+func NewGotifyNotifier(serverURL string, appToken string) *GotifyNotifier {
+	return &GotifyNotifier{
+		serverURL: strings.TrimRight(serverURL, "/"),
+		appToken:  appToken,
+	}
+}
+
+// Name returns the name of the messaging service.
+func (gn *GotifyNotifier) Name() string {
+	return "Gotify"
+}
+
+// send sends a Telegram HTML message as Markdown push notification.
+func (gn *GotifyNotifier) send(message string, clickURL string) error {
+	gotifyMessage := GotifyMessage{
+		Title:    messageFormats.Title(message),
+		Message:  messageFormats.HTMLToMarkdown(message),
+		Priority: DEFAULT_PRIORITY,
+		Extras: map[string]interface{}{
+			"client::display": map[string]string{"contentType": "text/markdown"},
+		},
+	}
+	if clickURL != "" {
+		gotifyMessage.Extras["client::notification"] = map[string]interface{}{"click": map[string]string{"url": clickURL}}
+	}
+	messageJson, err := json.Marshal(gotifyMessage)
+	if err != nil {
+		return fmt.Errorf("error marshalling gotify message: %w", err)
+	}
+
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	request.SetRequestURI(gn.serverURL + "/message")
+	request.Header.SetMethod("POST")
+	request.Header.SetContentType("application/json")
+	request.Header.Set("X-Gotify-Key", gn.appToken)
+	request.SetBody(messageJson)
+	err = fasthttp.Do(request, response)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		err = fmt.Errorf("gotify server returned status code %d: %s", response.StatusCode(), strings.TrimSpace(string(response.Body())))
+		return messaging.StatusError(response.StatusCode(), err)
+	}
+	return nil
+}
+
+// NotifyAsset sends a new/removed asset push notification.
+func (gn *GotifyNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return gn.send(telegramMessages.AssetMessage(removed, assetInfo), assetInfo.URL)
+}
+
+// NotifyAnnouncement sends a announcement push notification.
+func (gn *GotifyNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return gn.send(telegramMessages.AnnouncementMessage(announcement), announcement.URL)
+}
+
+// NotifyStatus sends a symbol status transition push notification.
+func (gn *GotifyNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return gn.send(telegramMessages.StatusMessage(transition), transition.URL)
+}
+
+// NotifyTrade sends a closed trade push notification.
+func (gn *GotifyNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return gn.send(telegramMessages.TradeMessage(trade), trade.URL)
+}
@@ -0,0 +1,41 @@
+// Description: Tests for the gotify package.
+
+package gotify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// TestNotifyAsset tests that the NotifyAsset function sends a Markdown push notification.
+func TestNotifyAsset(t *testing.T) {
+	var message GotifyMessage
+	var path, appToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &message)
+		path, appToken = r.URL.Path, r.Header.Get("X-Gotify-Key")
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+	gn := NewGotifyNotifier(server.URL+"/", "app_token")
+
+	err := gn.NotifyAsset(true, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != "/message" || appToken != "app_token" {
+		t.Errorf("Unexpected request: %s, %s", path, appToken)
+	}
+	if message.Title != "🗑 Binance removed asset (FOOUSDT)" || message.Priority != DEFAULT_PRIORITY {
+		t.Errorf("Unexpected message: %v", message)
+	}
+	if _, ok := message.Extras["client::notification"]; ok {
+		t.Errorf("Expected no click URL, got %v", message.Extras["client::notification"])
+	}
+}
@@ -0,0 +1,158 @@
+// Description: The matrix package contains functions for interacting with the Matrix client-server API.
+package matrix
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/messageFormats"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/telegram/telegramMessages"
+	"github.com/valyala/fasthttp"
+)
+
+// MatrixMessage represents a Matrix 'm.room.message' event.
+type MatrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// MatrixNotifier is a class that posts the checker events in Matrix rooms. It implements the messaging.Notifier interface.
+type MatrixNotifier struct {
+	homeserverURL string
+	accessToken   string
+	roomIDs       []string
+	destinations  []string // Rooms to post in (all if empty, see WithDestinations).
+	eventID       string   // ID of the posted events (random if empty, see WithEvent).
+}
+
+// NewMatrixNotifier creates a new MatrixNotifier that posts in the given rooms using a access token.
+func NewMatrixNotifier(homeserverURL string, accessToken string, roomIDs []string) *MatrixNotifier {
+	return &MatrixNotifier{
+		homeserverURL: strings.TrimRight(homeserverURL, "/"),
+		accessToken:   accessToken,
+		roomIDs:       roomIDs,
+	}
+}
+
+// Name returns the name of the messaging service.
+func (mn *MatrixNotifier) Name() string {
+	return "Matrix"
+}
+
+// WithDestinations returns a copy of the notifier that only posts in the given rooms. It implements the messaging.DestinationNotifier interface.
+func (mn *MatrixNotifier) WithDestinations(destinations []string) messaging.Notifier {
+	notifier := *mn
+	notifier.destinations = destinations
+	return &notifier
+}
+
+// WithEvent returns a copy of the notifier that posts the events with the given event ID. It implements the messaging.EventNotifier interface.
+func (mn *MatrixNotifier) WithEvent(eventID string, detectedAt time.Time) messaging.Notifier {
+	notifier := *mn
+	notifier.eventID = eventID
+	return &notifier
+}
+
+// newEventID returns a random event ID for events that are posted without a event ID (see WithEvent).
+func newEventID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(id)
+}
+
+// transactionID returns the transaction ID of a event in a room that prevents Matrix from posting retried events twice.
+// NOTE: The ID is derived from the room and the event ID so that only retries of the same event use the same ID.
+func transactionID(roomID string, eventID string) string {
+	hash := sha256.Sum256([]byte(roomID + "\n" + eventID))
+	return hex.EncodeToString(hash[:])
+}
+
+// sendMatrixMessage sends a Matrix message to a specified room.
+func (mn *MatrixNotifier) sendMatrixMessage(roomID string, eventID string, message MatrixMessage) error {
+	messageJson, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("error marshalling matrix message: %w", err)
+	}
+
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	request.SetRequestURI(fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s", mn.homeserverURL, url.PathEscape(roomID), transactionID(roomID, eventID)))
+	request.Header.SetMethod("PUT")
+	request.Header.SetContentType("application/json")
+	request.Header.Set("Authorization", "Bearer "+mn.accessToken)
+	request.SetBody(messageJson)
+	err = fasthttp.Do(request, response)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		err = fmt.Errorf("error sending matrix message to room '%s' (status code %d): %s", roomID, response.StatusCode(), strings.TrimSpace(string(response.Body())))
+		return messaging.StatusError(response.StatusCode(), err)
+	}
+	return nil
+}
+
+// send sends a Telegram HTML message to all rooms.
+func (mn *MatrixNotifier) send(message string) error {
+	matrixMessage := MatrixMessage{
+		MsgType:       "m.text",
+		Body:          strings.TrimSpace(messageFormats.HTMLToPlainText(message)),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: messageFormats.HTMLToMatrixHTML(message),
+	}
+
+	eventID := mn.eventID
+	if eventID == "" {
+		eventID = newEventID()
+	}
+
+	// NOTE: The errors are returned per room so that only the failed rooms are retried.
+	var errs []error
+	for _, roomID := range mn.roomIDs {
+		if !messaging.HasDestination(mn.destinations, roomID) {
+			continue
+		}
+		if err := mn.sendMatrixMessage(roomID, eventID, matrixMessage); err != nil {
+			errs = append(errs, &messaging.DestinationError{Destination: roomID, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifyAsset sends a new/removed asset Matrix message.
+func (mn *MatrixNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return mn.send(telegramMessages.AssetMessage(removed, assetInfo))
+}
+
+// NotifyAnnouncement sends a announcement Matrix message.
+func (mn *MatrixNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return mn.send(telegramMessages.AnnouncementMessage(announcement))
+}
+
+// NotifyStatus sends a symbol status transition Matrix message.
+func (mn *MatrixNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return mn.send(telegramMessages.StatusMessage(transition))
+}
+
+// NotifyTrade sends a closed trade Matrix message.
+func (mn *MatrixNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return mn.send(telegramMessages.TradeMessage(trade))
+}
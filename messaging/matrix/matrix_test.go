@@ -0,0 +1,81 @@
+// Description: Tests for the matrix package.
+
+package matrix
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+)
+
+// TestNotifyAnnouncement tests that the NotifyAnnouncement function sends a formatted message to each room.
+func TestNotifyAnnouncement(t *testing.T) {
+	var paths []string
+	var messages []MatrixMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var message MatrixMessage
+		json.Unmarshal(body, &message)
+		paths = append(paths, r.URL.EscapedPath())
+		messages = append(messages, message)
+		w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+	defer server.Close()
+	mn := NewMatrixNotifier(server.URL, "token", []string{"!a:matrix.org", "!b:matrix.org"})
+
+	err := mn.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(paths) != 2 || !strings.HasPrefix(paths[0], "/_matrix/client/v3/rooms/%21a:matrix.org/send/m.room.message/") {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+	if paths[0][strings.LastIndex(paths[0], "/"):] == paths[1][strings.LastIndex(paths[1], "/"):] {
+		t.Errorf("Expected unique transaction IDs per room, got %v", paths)
+	}
+
+	// Check that a identical new message uses a new transaction ID.
+	mn.WithDestinations([]string{"!a:matrix.org"}).NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
+	if len(paths) != 3 || paths[2] == paths[0] {
+		t.Errorf("Expected new transaction ID, got %v", paths)
+	}
+
+	// Check that a retried event uses the same transaction ID.
+	event := mn.WithEvent("event-1", time.Now())
+	event.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
+	event.(messaging.DestinationNotifier).WithDestinations([]string{"!a:matrix.org"}).NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
+	if len(paths) != 6 || paths[5] != paths[3] || paths[3] == paths[4] {
+		t.Errorf("Expected transaction ID of %s, got %v", paths[3], paths)
+	}
+	if messages[0].Body != "📢 test (https://www.google.com)" || messages[0].FormattedBody != "📢 <a href='https://www.google.com'>test</a>" {
+		t.Errorf("Unexpected message: %v", messages[0])
+	}
+}
+
+// TestNotifyError tests that client errors are returned as permanent errors of the room.
+func TestNotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	mn := NewMatrixNotifier(server.URL, "token", []string{"!a:matrix.org"})
+
+	err := mn.NotifyAsset(true, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT"})
+	var permanentErr *messaging.PermanentError
+	var destinationErr *messaging.DestinationError
+	if !errors.As(err, &permanentErr) || !errors.As(err, &destinationErr) || destinationErr.Destination != "!a:matrix.org" {
+		t.Errorf("Expected permanent error for room '!a:matrix.org', got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Description: The mattermost package contains functions for posting messages using Mattermost incoming webhooks.
+package mattermost
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/messageFormats"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/telegram/telegramMessages"
+	"github.com/valyala/fasthttp"
+)
+
+// MattermostMessage represents a Mattermost incoming webhook message.
+type MattermostMessage struct {
+	Text     string `json:"text"`
+	Username string `json:"username,omitempty"`
+}
+
+// MattermostNotifier is a class that posts the checker events using a Mattermost incoming webhook. It implements the messaging.Notifier interface.
+type MattermostNotifier struct {
+	webhookURL string
+}
+
+// NewMattermostNotifier creates a new MattermostNotifier that posts using a incoming webhook.
+func NewMattermostNotifier(webhookURL string) *MattermostNotifier {
+	return &MattermostNotifier{
+		webhookURL: webhookURL,
+	}
+}
+
+// Name returns the name of the messaging service.
+func (mn *MattermostNotifier) Name() string {
+	return "Mattermost"
+}
+
+// send sends a Telegram HTML message as Markdown to the incoming webhook.
+func (mn *MattermostNotifier) send(message string) error {
+	messageJson, err := json.Marshal(MattermostMessage{Text: messageFormats.HTMLToMarkdown(message), Username: "crypto-listings-sniper"})
+	if err != nil {
+		return fmt.Errorf("error marshalling mattermost message: %w", err)
+	}
+
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	request.SetRequestURI(mn.webhookURL)
+	request.Header.SetMethod("POST")
+	request.Header.SetContentType("application/json")
+	request.SetBody(messageJson)
+	err = fasthttp.Do(request, response)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		err = fmt.Errorf("mattermost webhook returned status code %d: %s", response.StatusCode(), strings.TrimSpace(string(response.Body())))
+		return messaging.StatusError(response.StatusCode(), err)
+	}
+	return nil
+}
+
+// NotifyAsset sends a new/removed asset Mattermost message.
+func (mn *MattermostNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return mn.send(telegramMessages.AssetMessage(removed, assetInfo))
+}
+
+// NotifyAnnouncement sends a announcement Mattermost message.
+func (mn *MattermostNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return mn.send(telegramMessages.AnnouncementMessage(announcement))
+}
+
+// NotifyStatus sends a symbol status transition Mattermost message.
+func (mn *MattermostNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return mn.send(telegramMessages.StatusMessage(transition))
+}
+
+// NotifyTrade sends a closed trade Mattermost message.
+func (mn *MattermostNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return mn.send(telegramMessages.TradeMessage(trade))
+}
@@ -0,0 +1,34 @@
+// Description: Tests for the mattermost package.
+
+package mattermost
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// TestNotifyAsset tests that the NotifyAsset function posts a Markdown message to the webhook.
+func TestNotifyAsset(t *testing.T) {
+	var message MattermostMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &message)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	mn := NewMattermostNotifier(server.URL)
+
+	err := mn.NotifyAsset(false, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", URL: "https://www.google.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := "💎 Binance listed new asset ([FOOUSDT](https://www.google.com))\n\n- **Base Asset:** FOO\n- **Quota Asset:** USDT\n"
+	if message.Text != expected {
+		t.Errorf("Expected %s, got %s", expected, message.Text)
+	}
+}
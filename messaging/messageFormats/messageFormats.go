@@ -0,0 +1,59 @@
+// Description: The messageFormats package contains functions for converting the Telegram HTML messages to the formats that are used by other messaging services.
+package messageFormats
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Regular expressions used to convert the Telegram HTML messages.
+var (
+	LINK_REGEX = regexp.MustCompile(`<a href='([^']*)'>(.*?)</a>`)
+	TAG_REGEX  = regexp.MustCompile(`</?[a-z]+[^>]*>`)
+)
+
+// replaceLinks replaces the HTML links using a given link format.
+// NOTE: Links without a URL are replaced by their text.
+func replaceLinks(message string, format func(url string, text string) string) string {
+	return LINK_REGEX.ReplaceAllStringFunc(message, func(link string) string {
+		match := LINK_REGEX.FindStringSubmatch(link)
+		if match[1] == "" {
+			return match[2]
+		}
+		return format(match[1], match[2])
+	})
+}
+
+// HTMLToMarkdown converts a Telegram HTML message to Markdown.
+func HTMLToMarkdown(message string) string {
+	message = replaceLinks(message, func(url string, text string) string {
+		return fmt.Sprintf("[%s](%s)", text, url)
+	})
+	message = strings.NewReplacer("<b>", "**", "</b>", "**").Replace(message)
+	return html.UnescapeString(TAG_REGEX.ReplaceAllString(message, ""))
+}
+
+// HTMLToPlainText converts a Telegram HTML message to plain text.
+func HTMLToPlainText(message string) string {
+	message = replaceLinks(message, func(url string, text string) string {
+		return fmt.Sprintf("%s (%s)", text, url)
+	})
+	return html.UnescapeString(TAG_REGEX.ReplaceAllString(message, ""))
+}
+
+// HTMLToMatrixHTML converts a Telegram HTML message to the HTML subset that is supported by Matrix clients.
+func HTMLToMatrixHTML(message string) string {
+	return strings.ReplaceAll(strings.TrimRight(message, "\n"), "\n", "<br>")
+}
+
+// Title returns the first line of a Telegram HTML message as plain text.
+func Title(message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return html.UnescapeString(TAG_REGEX.ReplaceAllString(line, ""))
+		}
+	}
+	return ""
+}
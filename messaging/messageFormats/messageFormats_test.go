@@ -0,0 +1,44 @@
+// Description: Tests for the messageFormats package.
+
+package messageFormats
+
+import (
+	"testing"
+)
+
+// MESSAGE is a Telegram HTML message that is used in the tests.
+const MESSAGE = "💎 <u>Binance listed new asset (<a href='https://www.google.com'>FOOUSDT</a>)</u>\n\n- <b>Base Asset:</b> FOO\n"
+
+// TestHTMLToMarkdown tests the HTMLToMarkdown function.
+func TestHTMLToMarkdown(t *testing.T) {
+	expected := "💎 Binance listed new asset ([FOOUSDT](https://www.google.com))\n\n- **Base Asset:** FOO\n"
+	if markdown := HTMLToMarkdown(MESSAGE); markdown != expected {
+		t.Errorf("Expected %s, got %s", expected, markdown)
+	}
+	if markdown := HTMLToMarkdown("<a href=''>FOO</a>"); markdown != "FOO" {
+		t.Errorf("Expected %s, got %s", "FOO", markdown)
+	}
+}
+
+// TestHTMLToPlainText tests the HTMLToPlainText function.
+func TestHTMLToPlainText(t *testing.T) {
+	expected := "💎 Binance listed new asset (FOOUSDT (https://www.google.com))\n\n- Base Asset: FOO\n"
+	if text := HTMLToPlainText(MESSAGE); text != expected {
+		t.Errorf("Expected %s, got %s", expected, text)
+	}
+}
+
+// TestHTMLToMatrixHTML tests the HTMLToMatrixHTML function.
+func TestHTMLToMatrixHTML(t *testing.T) {
+	expected := "💎 <u>Binance listed new asset (<a href='https://www.google.com'>FOOUSDT</a>)</u><br><br>- <b>Base Asset:</b> FOO"
+	if matrixHTML := HTMLToMatrixHTML(MESSAGE); matrixHTML != expected {
+		t.Errorf("Expected %s, got %s", expected, matrixHTML)
+	}
+}
+
+// TestTitle tests the Title function.
+func TestTitle(t *testing.T) {
+	if title := Title(MESSAGE); title != "💎 Binance listed new asset (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "💎 Binance listed new asset (FOOUSDT)", title)
+	}
+}
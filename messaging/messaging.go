@@ -1,35 +1,188 @@
-// Description: The messaging package contains general functions for sending messages to supported messaging services.
-// Note: Currently only Discord and Telegram are supported.
+// Description: The messaging package contains the general interface that is implemented by the supported messaging services and a dispatcher that fans out the checker events to them.
+// Note: The notifiers of the supported messaging services (e.g. Telegram, Discord and Slack) can be found in the sub-packages.
 
 package messaging
 
 import (
-	"github.com/adshao/go-binance/v2"
-	"github.com/bwmarrin/discordgo"
-	"github.com/mymmrac/telego"
-	dc "github.com/rickstaa/crypto-listings-sniper/messaging/discord"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
 
-	tg "github.com/rickstaa/crypto-listings-sniper/messaging/telegram"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 )
 
-// SendAssetMessage sends a new/removed assets message to the supported messaging services.
-func SendAssetMessage(telegramBot *telego.Bot, telegramChatID int64, enableTelegramMessage bool, discordBot *discordgo.Session, discordChannelIDs []string, enableDiscordMessages bool, removed bool, asset string, assetInfo binance.Symbol) {
-	if enableTelegramMessage {
-		go tg.SendAssetTelegramMessage(telegramBot, telegramChatID, removed, asset, assetInfo)
+// Notifier is the interface that needs to be implemented by messaging services to which the checker events are posted.
+type Notifier interface {
+	// Name returns the name of the messaging service.
+	Name() string
+	// NotifyAsset posts a new/removed asset message.
+	NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error
+	// NotifyAnnouncement posts a new announcement message.
+	NotifyAnnouncement(announcement exchanges.Announcement) error
+	// NotifyStatus posts a symbol status transition message.
+	NotifyStatus(transition exchanges.StatusTransition) error
+	// NotifyTrade posts a closed trade message.
+	NotifyTrade(trade exchanges.Trade) error
+}
+
+// RetryAfterError is returned by notifiers when the messaging service asks to wait before the next request (e.g. a Telegram 429 response).
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %v)", e.Err, e.RetryAfter)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// PermanentError is returned by notifiers when retrying the message will not help (e.g. because it was already stored in a dead-letter file).
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError returns a error for a failed HTTP request of a messaging service.
+// NOTE: Client errors (except 408 and 429) are returned as permanent errors since retrying will not help.
+func StatusError(statusCode int, err error) error {
+	if statusCode >= 400 && statusCode < 500 && statusCode != 408 && statusCode != 429 {
+		return &PermanentError{Err: err}
 	}
+	return err
+}
+
+// DestinationError is returned by notifiers that post in multiple destinations (e.g. Telegram chats) for each destination that failed.
+type DestinationError struct {
+	Destination string
+	Err         error
+}
+
+func (e *DestinationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DestinationError) Unwrap() error {
+	return e.Err
+}
+
+// DestinationNotifier is the interface that is implemented by notifiers that post in multiple destinations so that only the destinations that
+// failed (see DestinationError) have to be retried.
+type DestinationNotifier interface {
+	Notifier
+	// WithDestinations returns a copy of the notifier that only posts in the given destinations.
+	WithDestinations(destinations []string) Notifier
+}
+
+// EventNotifier is the interface that is implemented by notifiers that identify the events they post (e.g. so that retried events can be
+// de-duplicated).
+type EventNotifier interface {
+	Notifier
+	// WithEvent returns a copy of the notifier that posts the events with the given event ID and detection time.
+	WithEvent(eventID string, detectedAt time.Time) Notifier
+}
 
-	if enableDiscordMessages {
-		go dc.SendAssetDiscordMessage(discordBot, discordChannelIDs, removed, asset, assetInfo)
+// HasDestination returns whether a destination is part of the given destinations. All destinations are included if none are given.
+func HasDestination(destinations []string, destination string) bool {
+	if len(destinations) == 0 {
+		return true
 	}
+	for _, d := range destinations {
+		if d == destination {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatcher is a class that fans out the checker events to all registered notifiers. It implements the Notifier interface itself.
+type Dispatcher struct {
+	notifiers      []Notifier
+	notifiersMutex sync.RWMutex
 }
 
-// SendAnnouncementMessage sends a new announcement message to the messaging services.
-func SendAnnouncementMessage(telegramBot *telego.Bot, telegramChatID int64, enableTelegramMessage bool, discordBot *discordgo.Session, discordChannelIDs []string, enableDiscordMessages bool, announcementCode string, announcementTitle string) {
-	if enableTelegramMessage {
-		go tg.SendAnnouncementTelegramMessage(telegramBot, telegramChatID, announcementCode, announcementTitle)
+// NewDispatcher creates a new Dispatcher for the given notifiers.
+func NewDispatcher(notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{
+		notifiers: notifiers,
 	}
+}
+
+// Register registers a notifier with the dispatcher.
+func (d *Dispatcher) Register(notifier Notifier) {
+	d.notifiersMutex.Lock()
+	defer d.notifiersMutex.Unlock()
+	d.notifiers = append(d.notifiers, notifier)
+}
+
+// Notifiers returns the registered notifiers.
+func (d *Dispatcher) Notifiers() []Notifier {
+	d.notifiersMutex.RLock()
+	defer d.notifiersMutex.RUnlock()
+	return append([]Notifier(nil), d.notifiers...)
+}
 
-	if enableDiscordMessages {
-		go dc.SendAnnouncementDiscordMessage(discordBot, discordChannelIDs, announcementCode, announcementTitle)
+// Name returns the name of the dispatcher.
+func (d *Dispatcher) Name() string {
+	return "Dispatcher"
+}
+
+// dispatch calls notify for all registered notifiers concurrently and waits until they are done.
+// NOTE: Failed notifications are logged and returned as a joined error.
+func (d *Dispatcher) dispatch(notify func(notifier Notifier) error) error {
+	notifiers := d.Notifiers()
+	errs := make([]error, len(notifiers))
+	var wg sync.WaitGroup
+	for i, notifier := range notifiers {
+		wg.Add(1)
+		go func(i int, notifier Notifier) {
+			defer wg.Done()
+			errs[i] = notify(notifier)
+			if errs[i] != nil {
+				log.Printf("WARNING: Error sending %s message: %v", notifier.Name(), errs[i])
+			}
+		}(i, notifier)
 	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// NotifyAsset posts a new/removed asset message to all registered notifiers.
+func (d *Dispatcher) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return d.dispatch(func(notifier Notifier) error {
+		return notifier.NotifyAsset(removed, assetInfo)
+	})
+}
+
+// NotifyAnnouncement posts a new announcement message to all registered notifiers.
+func (d *Dispatcher) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return d.dispatch(func(notifier Notifier) error {
+		return notifier.NotifyAnnouncement(announcement)
+	})
+}
+
+// NotifyStatus posts a symbol status transition message to all registered notifiers.
+func (d *Dispatcher) NotifyStatus(transition exchanges.StatusTransition) error {
+	return d.dispatch(func(notifier Notifier) error {
+		return notifier.NotifyStatus(transition)
+	})
+}
+
+// NotifyTrade posts a closed trade message to all registered notifiers.
+func (d *Dispatcher) NotifyTrade(trade exchanges.Trade) error {
+	return d.dispatch(func(notifier Notifier) error {
+		return notifier.NotifyTrade(trade)
+	})
 }
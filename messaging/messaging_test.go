@@ -0,0 +1,96 @@
+// Description: Tests for the messaging package.
+
+package messaging
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// fakeNotifier is a notifier that records the events it receives.
+type fakeNotifier struct {
+	err           error
+	mutex         sync.Mutex
+	assets        []exchanges.SymbolInfo
+	announcements []exchanges.Announcement
+	transitions   []exchanges.StatusTransition
+	trades        []exchanges.Trade
+}
+
+func (fn *fakeNotifier) Name() string {
+	return "Fake"
+}
+
+func (fn *fakeNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	fn.assets = append(fn.assets, assetInfo)
+	return fn.err
+}
+
+func (fn *fakeNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	fn.announcements = append(fn.announcements, announcement)
+	return fn.err
+}
+
+func (fn *fakeNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	fn.transitions = append(fn.transitions, transition)
+	return fn.err
+}
+
+func (fn *fakeNotifier) NotifyTrade(trade exchanges.Trade) error {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	fn.trades = append(fn.trades, trade)
+	return fn.err
+}
+
+// TestDispatcher tests that the Dispatcher fans out the events to all registered notifiers.
+func TestDispatcher(t *testing.T) {
+	first, second := &fakeNotifier{}, &fakeNotifier{}
+	dispatcher := NewDispatcher(first)
+	dispatcher.Register(second)
+
+	if err := dispatcher.NotifyAsset(false, exchanges.SymbolInfo{Symbol: "FOOUSDT"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	dispatcher.NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	dispatcher.NotifyStatus(exchanges.StatusTransition{Symbol: "BARUSDT"})
+	dispatcher.NotifyTrade(exchanges.Trade{Symbol: "BAZUSDT"})
+	for _, notifier := range []*fakeNotifier{first, second} {
+		if len(notifier.assets) != 1 || notifier.assets[0].Symbol != "FOOUSDT" {
+			t.Errorf("Expected %v, got %v", []string{"FOOUSDT"}, notifier.assets)
+		}
+		if len(notifier.announcements) != 1 || notifier.announcements[0].Code != "a" {
+			t.Errorf("Expected %v, got %v", []string{"a"}, notifier.announcements)
+		}
+		if len(notifier.transitions) != 1 || notifier.transitions[0].Symbol != "BARUSDT" {
+			t.Errorf("Expected %v, got %v", []string{"BARUSDT"}, notifier.transitions)
+		}
+		if len(notifier.trades) != 1 || notifier.trades[0].Symbol != "BAZUSDT" {
+			t.Errorf("Expected %v, got %v", []string{"BAZUSDT"}, notifier.trades)
+		}
+	}
+}
+
+// TestDispatcherError tests that the Dispatcher returns the errors of failed notifiers while still notifying the others.
+func TestDispatcherError(t *testing.T) {
+	sendErr := errors.New("send failed")
+	failing, working := &fakeNotifier{err: sendErr}, &fakeNotifier{}
+	dispatcher := NewDispatcher(failing, working)
+
+	err := dispatcher.NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	if !errors.Is(err, sendErr) {
+		t.Errorf("Expected %v, got %v", sendErr, err)
+	}
+	if len(working.announcements) != 1 {
+		t.Errorf("Expected length of 1, got %d", len(working.announcements))
+	}
+}
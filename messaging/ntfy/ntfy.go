@@ -0,0 +1,92 @@
+// Description: The ntfy package contains functions for sending ntfy push notifications.
+package ntfy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/messageFormats"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/telegram/telegramMessages"
+	"github.com/valyala/fasthttp"
+)
+
+// NTFY_SERVER_URL is the default ntfy server.
+const NTFY_SERVER_URL = "https://ntfy.sh"
+
+// NtfyNotifier is a class that sends the checker events as ntfy push notifications. It implements the messaging.Notifier interface.
+type NtfyNotifier struct {
+	serverURL   string
+	topic       string
+	accessToken string
+}
+
+// NewNtfyNotifier creates a new NtfyNotifier that publishes to a topic on the given server.
+// NOTE: The access token is only required for protected topics.
+func NewNtfyNotifier(serverURL string, topic string, accessToken string) *NtfyNotifier {
+	if serverURL == "" {
+		serverURL = NTFY_SERVER_URL
+	}
+
+	return &NtfyNotifier{
+		serverURL:   strings.TrimRight(serverURL, "/"),
+		topic:       topic,
+		accessToken: accessToken,
+	}
+}
+
+// Name returns the name of the messaging service.
+func (nn *NtfyNotifier) Name() string {
+	return "ntfy"
+}
+
+// send publishes a Telegram HTML message as Markdown push notification.
+func (nn *NtfyNotifier) send(message string, clickURL string) error {
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	request.SetRequestURI(nn.serverURL + "/" + nn.topic)
+	request.Header.SetMethod("POST")
+	request.Header.Set("Title", messageFormats.Title(message))
+	request.Header.Set("Markdown", "yes")
+	if clickURL != "" {
+		request.Header.Set("Click", clickURL)
+	}
+	if nn.accessToken != "" {
+		request.Header.Set("Authorization", "Bearer "+nn.accessToken)
+	}
+	request.SetBodyString(messageFormats.HTMLToMarkdown(message))
+	err := fasthttp.Do(request, response)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		err = fmt.Errorf("ntfy server returned status code %d: %s", response.StatusCode(), strings.TrimSpace(string(response.Body())))
+		return messaging.StatusError(response.StatusCode(), err)
+	}
+	return nil
+}
+
+// NotifyAsset sends a new/removed asset push notification.
+func (nn *NtfyNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return nn.send(telegramMessages.AssetMessage(removed, assetInfo), assetInfo.URL)
+}
+
+// NotifyAnnouncement sends a announcement push notification.
+func (nn *NtfyNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return nn.send(telegramMessages.AnnouncementMessage(announcement), announcement.URL)
+}
+
+// NotifyStatus sends a symbol status transition push notification.
+func (nn *NtfyNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return nn.send(telegramMessages.StatusMessage(transition), transition.URL)
+}
+
+// NotifyTrade sends a closed trade push notification.
+func (nn *NtfyNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return nn.send(telegramMessages.TradeMessage(trade), trade.URL)
+}
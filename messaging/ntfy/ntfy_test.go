@@ -0,0 +1,38 @@
+// Description: Tests for the ntfy package.
+
+package ntfy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// TestNotifyAnnouncement tests that the NotifyAnnouncement function publishes a push notification to the topic.
+func TestNotifyAnnouncement(t *testing.T) {
+	var path, title, click, authorization, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		path, title, click, authorization, body = r.URL.Path, r.Header.Get("Title"), r.Header.Get("Click"), r.Header.Get("Authorization"), string(bodyBytes)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+	nn := NewNtfyNotifier(server.URL, "listings", "tk_test")
+
+	err := nn.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != "/listings" {
+		t.Errorf("Expected %s, got %s", "/listings", path)
+	}
+	if title != "📢 test" || click != "https://www.google.com" || authorization != "Bearer tk_test" {
+		t.Errorf("Unexpected headers: %s, %s, %s", title, click, authorization)
+	}
+	if body != "📢 [test](https://www.google.com)\n" {
+		t.Errorf("Expected %s, got %s", "📢 [test](https://www.google.com)\n", body)
+	}
+}
@@ -0,0 +1,421 @@
+// Description: The outbox package contains a persistent outbox that queues the checker events per notifier and delivers them with retries so that they survive failures and restarts.
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+)
+
+// Default delivery retry settings.
+// NOTE: Events are expired after DEFAULT_MAX_AGE so that the events of notifiers that are no longer used do not stay queued forever.
+const (
+	DEFAULT_MAX_ATTEMPTS = 10
+	DEFAULT_MIN_BACKOFF  = 1 * time.Second
+	DEFAULT_MAX_BACKOFF  = 5 * time.Minute
+	DEFAULT_MAX_AGE      = 24 * time.Hour
+)
+
+// Event types.
+const (
+	EVENT_ASSET        = "asset"
+	EVENT_ANNOUNCEMENT = "announcement"
+	EVENT_STATUS       = "status"
+	EVENT_TRADE        = "trade"
+)
+
+// Event represents a checker event that is queued in the outbox.
+// NOTE: The ID stays the same when the event is retried (see messaging.EventNotifier).
+type Event struct {
+	ID           string                      `json:"id,omitempty"`
+	Type         string                      `json:"type"`
+	Removed      bool                        `json:"removed,omitempty"`
+	Asset        *exchanges.SymbolInfo       `json:"asset,omitempty"`
+	Announcement *exchanges.Announcement     `json:"announcement,omitempty"`
+	Transition   *exchanges.StatusTransition `json:"transition,omitempty"`
+	Trade        *exchanges.Trade            `json:"trade,omitempty"`
+}
+
+// newEventID returns a random event ID.
+func newEventID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(id)
+}
+
+// deliver delivers the event using a given notifier.
+func (e Event) deliver(notifier messaging.Notifier) error {
+	switch {
+	case e.Type == EVENT_ASSET && e.Asset != nil:
+		return notifier.NotifyAsset(e.Removed, *e.Asset)
+	case e.Type == EVENT_ANNOUNCEMENT && e.Announcement != nil:
+		return notifier.NotifyAnnouncement(*e.Announcement)
+	case e.Type == EVENT_STATUS && e.Transition != nil:
+		return notifier.NotifyStatus(*e.Transition)
+	case e.Type == EVENT_TRADE && e.Trade != nil:
+		return notifier.NotifyTrade(*e.Trade)
+	default:
+		return fmt.Errorf("invalid %s event", e.Type)
+	}
+}
+
+// Entry represents a event that is queued for a single notifier.
+// NOTE: The destinations are only set for notifiers that implement the messaging.DestinationNotifier interface and contain the destinations
+// that still have to be delivered (all if empty).
+type Entry struct {
+	ID           int64     `json:"id"`
+	Sink         string    `json:"sink"`
+	Destinations []string  `json:"destinations,omitempty"`
+	Event        Event     `json:"event"`
+	Attempts     int       `json:"attempts"`
+	NextAttempt  time.Time `json:"next_attempt"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+// sink represents a notifier for which the outbox delivers the events.
+type sink struct {
+	name        string
+	notifier    messaging.Notifier
+	wake        chan struct{}
+	pausedUntil time.Time // Time until which the messaging service asked to wait (see messaging.RetryAfterError).
+}
+
+// Outbox is a class that persists the checker events and delivers them per notifier with exponential backoff.
+type Outbox struct {
+	path         string
+	entries      []*Entry
+	nextID       int64
+	entriesMutex sync.Mutex
+	sinks        map[string]*sink
+	maxAttempts  int
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	maxAge       time.Duration
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewOutbox creates a new Outbox that persists its events in the given file.
+// NOTE: Events that were queued before a restart are delivered once their notifier is wrapped again and expired otherwise.
+func NewOutbox(path string) (*Outbox, error) {
+	ob := &Outbox{
+		path:        path,
+		sinks:       make(map[string]*sink),
+		maxAttempts: DEFAULT_MAX_ATTEMPTS,
+		minBackoff:  DEFAULT_MIN_BACKOFF,
+		maxBackoff:  DEFAULT_MAX_BACKOFF,
+		maxAge:      DEFAULT_MAX_AGE,
+		done:        make(chan struct{}),
+	}
+
+	// Load queued events.
+	entriesJson, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading outbox file '%s': %w", path, err)
+	}
+	if len(entriesJson) != 0 {
+		err = json.Unmarshal(entriesJson, &ob.entries)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling outbox: %w", err)
+		}
+	}
+	for _, entry := range ob.entries {
+		if entry.ID >= ob.nextID {
+			ob.nextID = entry.ID + 1
+		}
+		if entry.Event.ID == "" { // NOTE: Events that were queued by older versions have no ID.
+			entry.Event.ID = newEventID()
+		}
+	}
+	ob.expire()
+
+	return ob, nil
+}
+
+// SetRetries sets the maximum number of delivery attempts and the backoff between them.
+func (ob *Outbox) SetRetries(maxAttempts int, minBackoff time.Duration, maxBackoff time.Duration) {
+	ob.maxAttempts = maxAttempts
+	ob.minBackoff = minBackoff
+	ob.maxBackoff = maxBackoff
+}
+
+// SetMaxAge sets the age after which queued events are dropped.
+func (ob *Outbox) SetMaxAge(maxAge time.Duration) {
+	ob.entriesMutex.Lock()
+	defer ob.entriesMutex.Unlock()
+	ob.maxAge = maxAge
+	ob.expire()
+}
+
+// expire drops the queued events that are older than the maximum age.
+// NOTE: Should be called with the entries mutex locked.
+func (ob *Outbox) expire() {
+	entries := ob.entries[:0]
+	for _, entry := range ob.entries {
+		if time.Since(entry.CreatedAt) > ob.maxAge {
+			log.Printf("WARNING: Dropping %s %s event that was queued at %v: %s", entry.Sink, entry.Event.Type, entry.CreatedAt, entry.LastError)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	ob.entries = entries
+}
+
+// Pending returns the number of queued events.
+func (ob *Outbox) Pending() int {
+	ob.entriesMutex.Lock()
+	defer ob.entriesMutex.Unlock()
+	return len(ob.entries)
+}
+
+// Close stops delivering the queued events.
+func (ob *Outbox) Close() {
+	ob.closeOnce.Do(func() { close(ob.done) })
+}
+
+// storeEntries writes the queued events to the outbox file.
+// NOTE: The events are written to a temporary file that is renamed afterwards so that a crash can not corrupt the file.
+func (ob *Outbox) storeEntries() error {
+	entriesJson, err := json.Marshal(ob.entries)
+	if err != nil {
+		return fmt.Errorf("error marshalling outbox: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(ob.path), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("error creating outbox folder: %w", err)
+	}
+	tmpPath := ob.path + ".tmp"
+	err = os.WriteFile(tmpPath, entriesJson, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing outbox file '%s': %w", tmpPath, err)
+	}
+	return os.Rename(tmpPath, ob.path)
+}
+
+// Wrap registers a notifier with the outbox and returns a notifier that queues its events in the outbox.
+// NOTE: Notifiers with the same name are numbered in registration order so that their queued events can be matched after a restart.
+func (ob *Outbox) Wrap(notifier messaging.Notifier) messaging.Notifier {
+	ob.entriesMutex.Lock()
+	name := notifier.Name()
+	for i := 2; ob.sinks[name] != nil; i++ {
+		name = fmt.Sprintf("%s#%d", notifier.Name(), i)
+	}
+	s := &sink{name: name, notifier: notifier, wake: make(chan struct{}, 1)}
+	ob.sinks[name] = s
+	ob.entriesMutex.Unlock()
+
+	go ob.deliver(s)
+	return &outboxNotifier{outbox: ob, sink: s}
+}
+
+// enqueue queues a event for a sink.
+func (ob *Outbox) enqueue(s *sink, event Event) error {
+	ob.entriesMutex.Lock()
+	ob.expire()
+	now := time.Now()
+	event.ID = newEventID()
+	ob.entries = append(ob.entries, &Entry{ID: ob.nextID, Sink: s.name, Event: event, NextAttempt: now, CreatedAt: now})
+	ob.nextID++
+	err := ob.storeEntries()
+	ob.entriesMutex.Unlock()
+
+	// Wake up the sink delivery loop.
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return err
+}
+
+// next returns the oldest queued event of a sink that is due or, if none is due, the time until the next event is due.
+// NOTE: Events that are waiting for a retry do not hold back the newer events of the sink.
+func (ob *Outbox) next(s *sink) (entry Entry, wait time.Duration, ok bool) {
+	ob.entriesMutex.Lock()
+	defer ob.entriesMutex.Unlock()
+	for _, e := range ob.entries {
+		if e.Sink != s.name {
+			continue
+		}
+		untilDue := time.Until(e.NextAttempt)
+		if untilDue <= 0 {
+			return *e, 0, true
+		}
+		if !ok || untilDue < wait {
+			wait, ok = untilDue, true
+		}
+	}
+	return entry, wait, ok
+}
+
+// update replaces or, if remove is set, removes a queued event.
+func (ob *Outbox) update(entry Entry, remove bool) {
+	ob.entriesMutex.Lock()
+	defer ob.entriesMutex.Unlock()
+	for i, e := range ob.entries {
+		if e.ID == entry.ID {
+			if remove {
+				ob.entries = append(ob.entries[:i], ob.entries[i+1:]...)
+			} else {
+				ob.entries[i] = &entry
+			}
+			break
+		}
+	}
+	err := ob.storeEntries()
+	if err != nil {
+		log.Printf("WARNING: Error storing outbox: %v", err)
+	}
+}
+
+// backoff returns the time to wait before the next delivery attempt.
+// NOTE: The wait time requested by the messaging service (e.g. Telegram 'retry_after') takes precedence.
+func (ob *Outbox) backoff(attempts int, err error) time.Duration {
+	var retryAfterErr *messaging.RetryAfterError
+	if errors.As(err, &retryAfterErr) && retryAfterErr.RetryAfter > 0 {
+		return retryAfterErr.RetryAfter
+	}
+
+	backoff := ob.minBackoff
+	for i := 1; i < attempts && backoff < ob.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > ob.maxBackoff {
+		backoff = ob.maxBackoff
+	}
+	return backoff
+}
+
+// failedDestinations returns the destinations of a notifier error that should be retried.
+// NOTE: The returned boolean is false when the error contains errors that do not belong to a destination (see messaging.DestinationError).
+func failedDestinations(err error) (destinations []string, ok bool) {
+	errs := []error{err}
+	if joinedErr, isJoined := err.(interface{ Unwrap() []error }); isJoined {
+		errs = joinedErr.Unwrap()
+	}
+	for _, err := range errs {
+		var destinationErr *messaging.DestinationError
+		if !errors.As(err, &destinationErr) {
+			return nil, false
+		}
+		var permanentErr *messaging.PermanentError
+		if !errors.As(err, &permanentErr) {
+			destinations = append(destinations, destinationErr.Destination)
+		}
+	}
+	return destinations, true
+}
+
+// deliver delivers the queued events of a sink oldest first.
+// NOTE: Events are retried with exponential backoff and dropped after the maximum number of attempts or a permanent error. Notifiers that
+// post in multiple destinations only retry the destinations that failed.
+func (ob *Outbox) deliver(s *sink) {
+	for {
+		select {
+		case <-ob.done:
+			return
+		default:
+		}
+
+		// Wait until the messaging service can be used again.
+		// NOTE: All events of the sink wait so that the rate limit is not extended and the events stay in order.
+		if wait := time.Until(s.pausedUntil); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ob.done:
+				return
+			}
+		}
+
+		// Wait until a event is queued or due.
+		entry, wait, ok := ob.next(s)
+		if !ok || wait > 0 {
+			var timer <-chan time.Time
+			if ok {
+				timer = time.After(wait)
+			}
+			select {
+			case <-s.wake:
+			case <-timer:
+			case <-ob.done:
+				return
+			}
+			continue
+		}
+
+		notifier := s.notifier
+		if eventNotifier, isEventNotifier := notifier.(messaging.EventNotifier); isEventNotifier {
+			notifier = eventNotifier.WithEvent(entry.Event.ID, entry.CreatedAt)
+		}
+		if destinationNotifier, isDestinationNotifier := notifier.(messaging.DestinationNotifier); isDestinationNotifier && len(entry.Destinations) != 0 {
+			notifier = destinationNotifier.WithDestinations(entry.Destinations)
+		}
+		err := entry.Event.deliver(notifier)
+		if err == nil {
+			ob.update(entry, true)
+			continue
+		}
+		entry.Attempts++
+		entry.LastError = err.Error()
+		var permanentErr *messaging.PermanentError
+		permanent := errors.As(err, &permanentErr)
+		if destinations, ok := failedDestinations(err); ok {
+			entry.Destinations, permanent = destinations, len(destinations) == 0
+		}
+		if permanent || entry.Attempts >= ob.maxAttempts {
+			log.Printf("WARNING: Dropping %s %s event after %d attempts: %v", s.name, entry.Event.Type, entry.Attempts, err)
+			ob.update(entry, true)
+			continue
+		}
+		entry.NextAttempt = time.Now().Add(ob.backoff(entry.Attempts, err))
+		var retryAfterErr *messaging.RetryAfterError
+		if errors.As(err, &retryAfterErr) {
+			s.pausedUntil = entry.NextAttempt
+		}
+		ob.update(entry, false)
+	}
+}
+
+// outboxNotifier is a notifier that queues the events of a sink in the outbox. It implements the messaging.Notifier interface.
+type outboxNotifier struct {
+	outbox *Outbox
+	sink   *sink
+}
+
+// Name returns the name of the wrapped notifier.
+func (on *outboxNotifier) Name() string {
+	return on.sink.name
+}
+
+// NotifyAsset queues a new/removed asset event.
+func (on *outboxNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return on.outbox.enqueue(on.sink, Event{Type: EVENT_ASSET, Removed: removed, Asset: &assetInfo})
+}
+
+// NotifyAnnouncement queues a announcement event.
+func (on *outboxNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return on.outbox.enqueue(on.sink, Event{Type: EVENT_ANNOUNCEMENT, Announcement: &announcement})
+}
+
+// NotifyStatus queues a symbol status transition event.
+func (on *outboxNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return on.outbox.enqueue(on.sink, Event{Type: EVENT_STATUS, Transition: &transition})
+}
+
+// NotifyTrade queues a closed trade event.
+func (on *outboxNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return on.outbox.enqueue(on.sink, Event{Type: EVENT_TRADE, Trade: &trade})
+}
@@ -0,0 +1,311 @@
+// Description: Tests for the outbox package.
+
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+)
+
+// fakeNotifier is a notifier that returns the queued errors before succeeding and records the delivered announcements.
+type fakeNotifier struct {
+	errs          []error
+	mutex         sync.Mutex
+	attempts      []time.Time
+	announcements []exchanges.Announcement
+}
+
+func (fn *fakeNotifier) Name() string {
+	return "Fake"
+}
+
+func (fn *fakeNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return nil
+}
+
+func (fn *fakeNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	fn.attempts = append(fn.attempts, time.Now())
+	if len(fn.errs) != 0 {
+		err := fn.errs[0]
+		if len(fn.errs) > 1 {
+			fn.errs = fn.errs[1:]
+		}
+		if err != nil {
+			return err
+		}
+	}
+	fn.announcements = append(fn.announcements, announcement)
+	return nil
+}
+
+func (fn *fakeNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return nil
+}
+
+func (fn *fakeNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return nil
+}
+
+// fakeDestinationNotifier is a notifier that posts in the destinations 'a', 'b' and 'c' and fails the destinations with a queued error once.
+type fakeDestinationNotifier struct {
+	*fakeNotifier
+	destinations []string
+	failures     map[string]error
+	delivered    map[string]int
+}
+
+func (fn *fakeDestinationNotifier) WithDestinations(destinations []string) messaging.Notifier {
+	notifier := *fn
+	notifier.destinations = destinations
+	return &notifier
+}
+
+func (fn *fakeDestinationNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	var errs []error
+	for _, destination := range []string{"a", "b", "c"} {
+		if !messaging.HasDestination(fn.destinations, destination) {
+			continue
+		}
+		if err := fn.failures[destination]; err != nil {
+			delete(fn.failures, destination)
+			errs = append(errs, &messaging.DestinationError{Destination: destination, Err: err})
+			continue
+		}
+		fn.delivered[destination]++
+	}
+	return errors.Join(errs...)
+}
+
+// fakeEventNotifier is a notifier that records the event IDs and detection times it is used with.
+type fakeEventNotifier struct {
+	*fakeNotifier
+	eventIDs    *[]string
+	detectedAts *[]time.Time
+}
+
+func (fn *fakeEventNotifier) WithEvent(eventID string, detectedAt time.Time) messaging.Notifier {
+	fn.mutex.Lock()
+	defer fn.mutex.Unlock()
+	*fn.eventIDs = append(*fn.eventIDs, eventID)
+	*fn.detectedAts = append(*fn.detectedAts, detectedAt)
+	return fn
+}
+
+// waitFor waits until a condition is met.
+func waitFor(t *testing.T, condition func() bool) {
+	for tStart := time.Now(); time.Since(tStart) < 5*time.Second; time.Sleep(time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+	t.Fatalf("Condition not met within timeout")
+}
+
+// TestRetryAfter tests that failed events are retried after the wait time requested by the messaging service.
+func TestRetryAfter(t *testing.T) {
+	ob, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	ob.SetRetries(5, time.Millisecond, time.Millisecond)
+	notifier := &fakeNotifier{errs: []error{&messaging.RetryAfterError{Err: errors.New("too many requests"), RetryAfter: 50 * time.Millisecond}, nil}}
+
+	err = ob.Wrap(notifier).NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	waitFor(t, func() bool { return ob.Pending() == 0 })
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if len(notifier.announcements) != 1 || notifier.announcements[0].Code != "a" {
+		t.Errorf("Expected %v, got %v", []string{"a"}, notifier.announcements)
+	}
+	if wait := notifier.attempts[1].Sub(notifier.attempts[0]); wait < 50*time.Millisecond {
+		t.Errorf("Expected retry after at least %v, got %v", 50*time.Millisecond, wait)
+	}
+}
+
+// TestRetryAfterPause tests that the other events of a notifier also wait for the time requested by the messaging service.
+func TestRetryAfterPause(t *testing.T) {
+	ob, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	ob.SetRetries(5, time.Millisecond, time.Millisecond)
+	notifier := &fakeNotifier{errs: []error{&messaging.RetryAfterError{Err: errors.New("too many requests"), RetryAfter: 50 * time.Millisecond}, nil}}
+	wrappedNotifier := ob.Wrap(notifier)
+
+	wrappedNotifier.NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	wrappedNotifier.NotifyAnnouncement(exchanges.Announcement{Code: "b"})
+	waitFor(t, func() bool { return ob.Pending() == 0 })
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if len(notifier.announcements) != 2 || notifier.announcements[0].Code != "a" || notifier.announcements[1].Code != "b" {
+		t.Errorf("Expected %v, got %v", []string{"a", "b"}, notifier.announcements)
+	}
+	if len(notifier.attempts) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(notifier.attempts))
+	}
+	if wait := notifier.attempts[1].Sub(notifier.attempts[0]); wait < 50*time.Millisecond {
+		t.Errorf("Expected next attempt after at least %v, got %v", 50*time.Millisecond, wait)
+	}
+}
+
+// TestEventID tests that a retried event is delivered with the same event ID and detection time.
+func TestEventID(t *testing.T) {
+	ob, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	ob.SetRetries(5, time.Millisecond, time.Millisecond)
+	notifier := &fakeEventNotifier{fakeNotifier: &fakeNotifier{errs: []error{errors.New("unavailable"), nil}}, eventIDs: &[]string{}, detectedAts: &[]time.Time{}}
+
+	for _, code := range []string{"a", "b"} {
+		if err := ob.Wrap(notifier).NotifyAnnouncement(exchanges.Announcement{Code: code}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		waitFor(t, func() bool { return ob.Pending() == 0 })
+	}
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	eventIDs, detectedAts := *notifier.eventIDs, *notifier.detectedAts
+	if len(eventIDs) != 3 {
+		t.Fatalf("Expected %d deliveries, got %d", 3, len(eventIDs))
+	}
+	if eventIDs[0] == "" || eventIDs[0] != eventIDs[1] || !detectedAts[0].Equal(detectedAts[1]) {
+		t.Errorf("Expected the retry to reuse %s and %v, got %s and %v", eventIDs[0], detectedAts[0], eventIDs[1], detectedAts[1])
+	}
+	if eventIDs[2] == eventIDs[0] {
+		t.Errorf("Expected a new event ID, got %s", eventIDs[2])
+	}
+}
+
+// TestMaxAttempts tests that events are dropped after the maximum number of attempts.
+func TestMaxAttempts(t *testing.T) {
+	ob, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	ob.SetRetries(3, time.Millisecond, time.Millisecond)
+	notifier := &fakeNotifier{errs: []error{errors.New("unavailable")}}
+
+	ob.Wrap(notifier).NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	waitFor(t, func() bool { return ob.Pending() == 0 })
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if len(notifier.attempts) != 3 || len(notifier.announcements) != 0 {
+		t.Errorf("Expected 3 attempts and no deliveries, got %d and %d", len(notifier.attempts), len(notifier.announcements))
+	}
+}
+
+// TestRestart tests that queued events are delivered after a restart.
+func TestRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	ob, err := NewOutbox(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ob.Close() // NOTE: Stops the delivery so that the event stays queued.
+	ob.Wrap(&fakeNotifier{}).NotifyAnnouncement(exchanges.Announcement{Code: "a", Title: "A"})
+
+	restartedOb, err := NewOutbox(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer restartedOb.Close()
+	if restartedOb.Pending() != 1 {
+		t.Fatalf("Expected 1 pending event, got %d", restartedOb.Pending())
+	}
+	notifier := &fakeNotifier{}
+	restartedOb.Wrap(notifier)
+	waitFor(t, func() bool { return restartedOb.Pending() == 0 })
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if len(notifier.announcements) != 1 || notifier.announcements[0].Title != "A" {
+		t.Errorf("Expected %v, got %v", []string{"A"}, notifier.announcements)
+	}
+}
+
+// TestRetryBlocked tests that a event that waits for a retry does not hold back the newer events.
+func TestRetryBlocked(t *testing.T) {
+	ob, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	ob.SetRetries(5, time.Hour, time.Hour)
+	notifier := &fakeNotifier{errs: []error{errors.New("unavailable"), nil}}
+	wrappedNotifier := ob.Wrap(notifier)
+
+	wrappedNotifier.NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	waitFor(t, func() bool {
+		notifier.mutex.Lock()
+		defer notifier.mutex.Unlock()
+		return len(notifier.attempts) == 1
+	})
+	wrappedNotifier.NotifyAnnouncement(exchanges.Announcement{Code: "b"})
+	waitFor(t, func() bool { return ob.Pending() == 1 })
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if len(notifier.announcements) != 1 || notifier.announcements[0].Code != "b" {
+		t.Errorf("Expected %v, got %v", []string{"b"}, notifier.announcements)
+	}
+}
+
+// TestDestinations tests that only the destinations that failed are retried and that permanently failed destinations are not retried.
+func TestDestinations(t *testing.T) {
+	ob, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	ob.SetRetries(5, time.Millisecond, time.Millisecond)
+	notifier := &fakeDestinationNotifier{
+		fakeNotifier: &fakeNotifier{},
+		failures:     map[string]error{"b": errors.New("unavailable"), "c": &messaging.PermanentError{Err: errors.New("not found")}},
+		delivered:    make(map[string]int),
+	}
+
+	ob.Wrap(notifier).NotifyAnnouncement(exchanges.Announcement{Code: "a"})
+	waitFor(t, func() bool { return ob.Pending() == 0 })
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if notifier.delivered["a"] != 1 || notifier.delivered["b"] != 1 || notifier.delivered["c"] != 0 {
+		t.Errorf("Expected %v, got %v", map[string]int{"a": 1, "b": 1}, notifier.delivered)
+	}
+}
+
+// TestExpire tests that queued events of notifiers that are not wrapped again are dropped after the maximum age.
+func TestExpire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	entriesJson, _ := json.Marshal([]Entry{
+		{ID: 1, Sink: "Removed", Event: Event{Type: EVENT_ANNOUNCEMENT}, CreatedAt: time.Now().Add(-2 * DEFAULT_MAX_AGE)},
+		{ID: 2, Sink: "Fake", Event: Event{Type: EVENT_ANNOUNCEMENT}, CreatedAt: time.Now()},
+	})
+	os.WriteFile(path, entriesJson, 0644)
+
+	ob, err := NewOutbox(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ob.Close()
+	if ob.Pending() != 1 {
+		t.Errorf("Expected 1 pending event, got %d", ob.Pending())
+	}
+}
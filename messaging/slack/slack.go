@@ -0,0 +1,158 @@
+// Description: The slack package contains functions for interacting with the Slack API.
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/slack/slackBlocks"
+	"github.com/valyala/fasthttp"
+)
+
+// SLACK_API_ENDPOINT is the default Slack Web API endpoint.
+const SLACK_API_ENDPOINT = "https://slack.com/api"
+
+// slackResponse represents the response of the Slack Web API.
+type slackResponse struct {
+	Ok    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// SlackNotifier is a class that posts the checker events in Slack using a incoming webhook or the 'chat.postMessage' method. It implements the messaging.Notifier interface.
+type SlackNotifier struct {
+	webhookURL   string
+	botToken     string
+	channelIDs   []string
+	apiEndpoint  string
+	destinations []string // Channels to post in (all if empty, see WithDestinations).
+}
+
+// NewSlackWebhookNotifier creates a new SlackNotifier that posts using a incoming webhook.
+func NewSlackWebhookNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL:  webhookURL,
+		apiEndpoint: SLACK_API_ENDPOINT,
+	}
+}
+
+// NewSlackBotNotifier creates a new SlackNotifier that posts in the given channels using a bot token.
+func NewSlackBotNotifier(botToken string, channelIDs []string) *SlackNotifier {
+	return &SlackNotifier{
+		botToken:    botToken,
+		channelIDs:  channelIDs,
+		apiEndpoint: SLACK_API_ENDPOINT,
+	}
+}
+
+// SetApiEndpoint sets the Slack Web API endpoint.
+func (sn *SlackNotifier) SetApiEndpoint(apiEndpoint string) {
+	sn.apiEndpoint = apiEndpoint
+}
+
+// WithDestinations returns a copy of the notifier that only posts in the given channels. It implements the messaging.DestinationNotifier interface.
+func (sn *SlackNotifier) WithDestinations(destinations []string) messaging.Notifier {
+	notifier := *sn
+	notifier.destinations = destinations
+	return &notifier
+}
+
+// Name returns the name of the messaging service.
+func (sn *SlackNotifier) Name() string {
+	return "Slack"
+}
+
+// post performs a POST request with a JSON payload and returns the response body.
+func (sn *SlackNotifier) post(url string, payload interface{}) ([]byte, error) {
+	payloadJson, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling slack message: %w", err)
+	}
+
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	request.SetRequestURI(url)
+	request.Header.SetMethod("POST")
+	request.Header.SetContentType("application/json; charset=utf-8")
+	if sn.botToken != "" {
+		request.Header.Set("Authorization", "Bearer "+sn.botToken)
+	}
+	request.SetBody(payloadJson)
+	err = fasthttp.Do(request, response)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 {
+		err = fmt.Errorf("slack endpoint returned status code %d: %s", response.StatusCode(), strings.TrimSpace(string(response.Body())))
+		return nil, messaging.StatusError(response.StatusCode(), err)
+	}
+
+	return append([]byte(nil), response.Body()...), nil
+}
+
+// sendSlackMessage sends a Slack message using the incoming webhook or the 'chat.postMessage' method.
+func (sn *SlackNotifier) sendSlackMessage(message slackBlocks.Message) error {
+	if sn.webhookURL != "" {
+		_, err := sn.post(sn.webhookURL, message)
+		return err
+	}
+
+	// NOTE: The errors are returned per channel so that only the failed channels are retried.
+	var errs []error
+	for _, channelID := range sn.channelIDs {
+		if !messaging.HasDestination(sn.destinations, channelID) {
+			continue
+		}
+		message.Channel = channelID
+		if err := sn.postMessage(message); err != nil {
+			errs = append(errs, &messaging.DestinationError{Destination: channelID, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postMessage sends a Slack message to the channel of the message using the 'chat.postMessage' method.
+func (sn *SlackNotifier) postMessage(message slackBlocks.Message) error {
+	body, err := sn.post(sn.apiEndpoint+"/chat.postMessage", message)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The Web API returns errors with a 200 status code.
+	var response slackResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling slack response: %w", err)
+	}
+	if !response.Ok {
+		return fmt.Errorf("error sending slack message to channel '%s': %s", message.Channel, response.Error)
+	}
+	return nil
+}
+
+// NotifyAsset sends a new/removed asset Slack message.
+func (sn *SlackNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return sn.sendSlackMessage(slackBlocks.AssetBlocks(removed, assetInfo))
+}
+
+// NotifyAnnouncement sends a announcement Slack message.
+func (sn *SlackNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return sn.sendSlackMessage(slackBlocks.AnnouncementBlocks(announcement))
+}
+
+// NotifyStatus sends a symbol status transition Slack message.
+func (sn *SlackNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return sn.sendSlackMessage(slackBlocks.StatusBlocks(transition))
+}
+
+// NotifyTrade sends a closed trade Slack message.
+func (sn *SlackNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return sn.sendSlackMessage(slackBlocks.TradeBlocks(trade))
+}
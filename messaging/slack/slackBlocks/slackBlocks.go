@@ -0,0 +1,243 @@
+// Description: The slackBlocks package contains functions for creating Slack Block Kit messages.
+package slackBlocks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+)
+
+// ASSET_IMAGE_URL is the image that is shown in new asset and announcement messages.
+const ASSET_IMAGE_URL = "https://t4.ftcdn.net/jpg/04/46/35/17/360_F_446351747_WHAenLH7njEwEAuDf3aJ7Q3WFX9FM18s.jpg"
+
+// TextObject represents a Slack Block Kit text object.
+type TextObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Block represents a Slack Block Kit layout block.
+type Block struct {
+	Type     string        `json:"type"`
+	Text     *TextObject   `json:"text,omitempty"`
+	Fields   []*TextObject `json:"fields,omitempty"`
+	Elements []*TextObject `json:"elements,omitempty"`
+	ImageURL string        `json:"image_url,omitempty"`
+	AltText  string        `json:"alt_text,omitempty"`
+}
+
+// Message represents a Slack Block Kit message.
+// NOTE: The text is used as fallback in notifications.
+type Message struct {
+	Channel string  `json:"channel,omitempty"`
+	Text    string  `json:"text"`
+	Blocks  []Block `json:"blocks"`
+}
+
+// plainText returns a plain text object.
+func plainText(text string) *TextObject {
+	return &TextObject{Type: "plain_text", Text: text}
+}
+
+// markdown returns a mrkdwn text object.
+func markdown(text string) *TextObject {
+	return &TextObject{Type: "mrkdwn", Text: text}
+}
+
+// link returns a mrkdwn link or the text if the URL is empty.
+func link(url string, text string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("<%s|%s>", url, text)
+}
+
+// newAssetBlocks returns a new asset message.
+func newAssetBlocks(symbolInfo exchanges.SymbolInfo) Message {
+	title := fmt.Sprintf("💎 %s listed new asset (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
+	fields := []*TextObject{
+		markdown(fmt.Sprintf("*Base Asset:*\n%s", symbolInfo.BaseAsset)),
+		markdown(fmt.Sprintf("*Quota Asset:*\n%s", symbolInfo.QuoteAsset)),
+	}
+	if symbolInfo.Status != "" {
+		fields = append(fields, markdown(fmt.Sprintf("*Status:*\n%s", symbolInfo.Status)))
+	}
+	return Message{
+		Text: title,
+		Blocks: []Block{
+			{Type: "header", Text: plainText(title)},
+			{Type: "section", Text: markdown(link(symbolInfo.URL, symbolInfo.Symbol)), Fields: fields},
+			{Type: "image", ImageURL: ASSET_IMAGE_URL, AltText: symbolInfo.Symbol},
+		},
+	}
+}
+
+// newFuturesContractBlocks returns a new futures contract message.
+func newFuturesContractBlocks(symbolInfo exchanges.SymbolInfo) Message {
+	title := fmt.Sprintf("📈 %s listed new futures contract (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
+	fields := []*TextObject{
+		markdown(fmt.Sprintf("*Base Asset:*\n%s", symbolInfo.BaseAsset)),
+		markdown(fmt.Sprintf("*Quota Asset:*\n%s", symbolInfo.QuoteAsset)),
+		markdown(fmt.Sprintf("*Contract Type:*\n%s", symbolInfo.ContractType)),
+	}
+	if !symbolInfo.OnboardDate.IsZero() {
+		fields = append(fields, markdown(fmt.Sprintf("*Onboard Date:*\n%s", symbolInfo.OnboardDate.UTC().Format("2006-01-02 15:04 MST"))))
+	}
+	if symbolInfo.Status != "" {
+		fields = append(fields, markdown(fmt.Sprintf("*Status:*\n%s", symbolInfo.Status)))
+	}
+	return Message{
+		Text: title,
+		Blocks: []Block{
+			{Type: "header", Text: plainText(title)},
+			{Type: "section", Text: markdown(link(symbolInfo.URL, symbolInfo.Symbol)), Fields: fields},
+		},
+	}
+}
+
+// newProductAssetBlocks returns a new product asset message.
+func newProductAssetBlocks(symbolInfo exchanges.SymbolInfo) Message {
+	title := fmt.Sprintf("🏦 %s added new asset (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
+	fields := []*TextObject{
+		markdown(fmt.Sprintf("*Base Asset:*\n%s", symbolInfo.BaseAsset)),
+	}
+	if symbolInfo.QuoteAsset != "" {
+		fields = append(fields, markdown(fmt.Sprintf("*Quota Asset:*\n%s", symbolInfo.QuoteAsset)))
+	}
+	if symbolInfo.Status != "" {
+		fields = append(fields, markdown(fmt.Sprintf("*Status:*\n%s", symbolInfo.Status)))
+	}
+	return Message{
+		Text: title,
+		Blocks: []Block{
+			{Type: "header", Text: plainText(title)},
+			{Type: "section", Text: markdown(link(symbolInfo.URL, symbolInfo.Symbol)), Fields: fields},
+		},
+	}
+}
+
+// removedAssetBlocks returns a removed asset message.
+func removedAssetBlocks(symbolInfo exchanges.SymbolInfo) Message {
+	title := fmt.Sprintf("🗑 %s removed asset (%s)", symbolInfo.Exchange, symbolInfo.Symbol)
+	return Message{
+		Text:   title,
+		Blocks: []Block{{Type: "header", Text: plainText(title)}},
+	}
+}
+
+// AssetBlocks returns a asset Slack message.
+func AssetBlocks(removed bool, assetInfo exchanges.SymbolInfo) Message {
+	if removed {
+		return removedAssetBlocks(assetInfo)
+	}
+	if assetInfo.IsFuturesContract() {
+		return newFuturesContractBlocks(assetInfo)
+	}
+	if assetInfo.IsProduct() {
+		return newProductAssetBlocks(assetInfo)
+	}
+	return newAssetBlocks(assetInfo)
+}
+
+// AnnouncementBlocks returns a new announcement message.
+// NOTE: The structured fields are only added when they were extracted from the title.
+func AnnouncementBlocks(announcement exchanges.Announcement) Message {
+	title := fmt.Sprintf("📢 %s", announcement.Title)
+	message := Message{
+		Text:   title,
+		Blocks: []Block{},
+	}
+	if announcement.CatalogName != "" {
+		message.Blocks = append(message.Blocks, Block{Type: "context", Elements: []*TextObject{markdown(announcement.CatalogName)}})
+	}
+
+	var fields []*TextObject
+	if announcement.Kind != "" && announcement.Kind != exchanges.ANNOUNCEMENT_OTHER {
+		fields = append(fields, markdown(fmt.Sprintf("*Kind:*\n%s", announcement.Kind)))
+	}
+	if len(announcement.Tickers) != 0 {
+		fields = append(fields, markdown(fmt.Sprintf("*Tickers:*\n%s", strings.Join(announcement.Tickers, ", "))))
+	}
+	if len(announcement.Pairs) != 0 {
+		fields = append(fields, markdown(fmt.Sprintf("*Pairs:*\n%s", strings.Join(announcement.Pairs, ", "))))
+	}
+	if len(announcement.Dates) != 0 {
+		fields = append(fields, markdown(fmt.Sprintf("*Dates:*\n%s", strings.Join(announcement.Dates, ", "))))
+	}
+	message.Blocks = append(message.Blocks,
+		Block{Type: "section", Text: markdown(fmt.Sprintf("📢 *%s*", link(announcement.URL, announcement.Title))), Fields: fields},
+		Block{Type: "image", ImageURL: ASSET_IMAGE_URL, AltText: announcement.Title},
+	)
+	return message
+}
+
+// statusTitle returns the title of a symbol status transition message.
+func statusTitle(transition exchanges.StatusTransition) string {
+	switch {
+	case transition.OldStatus == "" && transition.NewStatus == exchanges.STATUS_PRE_TRADING:
+		return fmt.Sprintf("🔜 %s added pre-trading asset (%s)", transition.Exchange, transition.Symbol)
+	case transition.OldStatus == exchanges.STATUS_PRE_TRADING && transition.NewStatus == exchanges.STATUS_TRADING:
+		return fmt.Sprintf("🚀 %s opened trading (%s)", transition.Exchange, transition.Symbol)
+	case transition.NewStatus == exchanges.STATUS_BREAK:
+		return fmt.Sprintf("⏸ %s halted trading (%s)", transition.Exchange, transition.Symbol)
+	case transition.OldStatus == exchanges.STATUS_BREAK && transition.NewStatus == exchanges.STATUS_TRADING:
+		return fmt.Sprintf("▶️ %s resumed trading (%s)", transition.Exchange, transition.Symbol)
+	default:
+		return fmt.Sprintf("🔄 %s changed trading status (%s)", transition.Exchange, transition.Symbol)
+	}
+}
+
+// StatusBlocks returns a symbol status transition message.
+func StatusBlocks(transition exchanges.StatusTransition) Message {
+	oldStatus := transition.OldStatus
+	if oldStatus == "" {
+		oldStatus = "NONE"
+	}
+	title := statusTitle(transition)
+	return Message{
+		Text: title,
+		Blocks: []Block{
+			{Type: "section", Text: markdown(fmt.Sprintf("*%s*", link(transition.URL, title)))},
+			{Type: "section", Fields: []*TextObject{
+				markdown(fmt.Sprintf("*Status:*\n%s → %s", oldStatus, transition.NewStatus)),
+				markdown(fmt.Sprintf("*Time:*\n%s", transition.Time.UTC().Format(time.RFC3339))),
+			}},
+		},
+	}
+}
+
+// tradeTitle returns the title of a closed trade message.
+// NOTE: Simulated trades are marked as paper positions.
+func tradeTitle(trade exchanges.Trade) string {
+	position := "position"
+	if trade.Simulated {
+		position = "paper position"
+	}
+	if trade.PnL < 0 {
+		return fmt.Sprintf("🔻 %s closed %s with loss (%s)", trade.Exchange, position, trade.Symbol)
+	}
+	return fmt.Sprintf("💰 %s closed %s with profit (%s)", trade.Exchange, position, trade.Symbol)
+}
+
+// TradeBlocks returns a closed trade message with the fill prices and PnL.
+func TradeBlocks(trade exchanges.Trade) Message {
+	title := tradeTitle(trade)
+	return Message{
+		Text: title,
+		Blocks: []Block{
+			{Type: "section", Text: markdown(fmt.Sprintf("*%s*", link(trade.URL, title)))},
+			{Type: "section", Fields: []*TextObject{
+				markdown(fmt.Sprintf("*Quantity:*\n%s %s", utils.FormatAmount(trade.Quantity), trade.BaseAsset)),
+				markdown(fmt.Sprintf("*Entry Price:*\n%s %s", utils.FormatAmount(trade.EntryPrice), trade.QuoteAsset)),
+				markdown(fmt.Sprintf("*Exit Price:*\n%s %s", utils.FormatAmount(trade.ExitPrice), trade.QuoteAsset)),
+				markdown(fmt.Sprintf("*Fees:*\n%s %s", utils.FormatAmount(trade.Fees), trade.QuoteAsset)),
+				markdown(fmt.Sprintf("*PnL:*\n%s %s (%+.2f%%)", utils.FormatAmount(trade.PnL), trade.QuoteAsset, trade.PnLPercentage())),
+				markdown(fmt.Sprintf("*Exit Reason:*\n%s", strings.ReplaceAll(trade.ExitReason, "_", " "))),
+				markdown(fmt.Sprintf("*Holding Time:*\n%v", trade.ExitTime.Sub(trade.EntryTime).Round(time.Second))),
+			}},
+		},
+	}
+}
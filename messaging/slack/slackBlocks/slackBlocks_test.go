@@ -0,0 +1,67 @@
+// Description: Tests for the slackBlocks package.
+
+package slackBlocks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// TestAssetBlocks tests the AssetBlocks function.
+func TestAssetBlocks(t *testing.T) {
+	message := AssetBlocks(false, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", URL: "https://www.google.com"})
+	if message.Text != "💎 Binance listed new asset (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "💎 Binance listed new asset (FOOUSDT)", message.Text)
+	}
+	if len(message.Blocks) != 3 {
+		t.Fatalf("Expected length of 3, got %d", len(message.Blocks))
+	}
+	if message.Blocks[1].Text.Text != "<https://www.google.com|FOOUSDT>" {
+		t.Errorf("Expected %s, got %s", "<https://www.google.com|FOOUSDT>", message.Blocks[1].Text.Text)
+	}
+	if len(message.Blocks[1].Fields) != 2 || message.Blocks[1].Fields[0].Text != "*Base Asset:*\nFOO" {
+		t.Errorf("Unexpected fields: %v", message.Blocks[1].Fields)
+	}
+}
+
+// TestRemovedAssetBlocks tests the AssetBlocks function for removed assets.
+func TestRemovedAssetBlocks(t *testing.T) {
+	message := AssetBlocks(true, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT"})
+	if message.Text != "🗑 Binance removed asset (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "🗑 Binance removed asset (FOOUSDT)", message.Text)
+	}
+	if len(message.Blocks) != 1 {
+		t.Errorf("Expected length of 1, got %d", len(message.Blocks))
+	}
+}
+
+// TestAnnouncementBlocks tests the AnnouncementBlocks function.
+func TestAnnouncementBlocks(t *testing.T) {
+	message := AnnouncementBlocks(exchanges.Announcement{URL: "https://www.google.com", Title: "Test", CatalogName: "Delisting", Kind: "delisting", Tickers: []string{"FOO", "BAR"}})
+	if message.Text != "📢 Test" {
+		t.Errorf("Expected %s, got %s", "📢 Test", message.Text)
+	}
+	if len(message.Blocks) != 3 {
+		t.Fatalf("Expected length of 3, got %d", len(message.Blocks))
+	}
+	if message.Blocks[0].Elements[0].Text != "Delisting" {
+		t.Errorf("Expected %s, got %s", "Delisting", message.Blocks[0].Elements[0].Text)
+	}
+	if len(message.Blocks[1].Fields) != 2 || message.Blocks[1].Fields[1].Text != "*Tickers:*\nFOO, BAR" {
+		t.Errorf("Unexpected fields: %v", message.Blocks[1].Fields)
+	}
+}
+
+// TestStatusBlocks tests the StatusBlocks function.
+func TestStatusBlocks(t *testing.T) {
+	transition := exchanges.StatusTransition{Exchange: "Binance", Symbol: "FOOUSDT", OldStatus: "TRADING", NewStatus: "BREAK", Time: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)}
+	message := StatusBlocks(transition)
+	if message.Text != "⏸ Binance halted trading (FOOUSDT)" {
+		t.Errorf("Expected %s, got %s", "⏸ Binance halted trading (FOOUSDT)", message.Text)
+	}
+	if message.Blocks[1].Fields[0].Text != "*Status:*\nTRADING → BREAK" {
+		t.Errorf("Expected %s, got %s", "*Status:*\nTRADING → BREAK", message.Blocks[1].Fields[0].Text)
+	}
+}
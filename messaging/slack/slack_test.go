@@ -0,0 +1,101 @@
+// Description: Tests for the slack package.
+
+package slack
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging/slack/slackBlocks"
+)
+
+// newSlackStandIn returns a local HTTP server that stands in for the Slack webhook and Web API and records the received messages.
+func newSlackStandIn(t *testing.T, messages *[]slackBlocks.Message, authorizations *[]string) *httptest.Server {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var message slackBlocks.Message
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		*messages = append(*messages, message)
+		*authorizations = append(*authorizations, r.Header.Get("Authorization"))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/chat.postMessage", func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r)
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+// TestWebhookNotifyAsset tests that the NotifyAsset function posts a Block Kit message to the webhook.
+func TestWebhookNotifyAsset(t *testing.T) {
+	var messages []slackBlocks.Message
+	var authorizations []string
+	server := newSlackStandIn(t, &messages, &authorizations)
+	defer server.Close()
+	sn := NewSlackWebhookNotifier(server.URL + "/webhook")
+
+	err := sn.NotifyAsset(false, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 || messages[0].Text != "💎 Binance listed new asset (FOOUSDT)" {
+		t.Errorf("Expected %s, got %v", "💎 Binance listed new asset (FOOUSDT)", messages)
+	}
+	if len(messages[0].Blocks) != 3 || messages[0].Blocks[0].Type != "header" {
+		t.Errorf("Unexpected blocks: %v", messages[0].Blocks)
+	}
+	if authorizations[0] != "" {
+		t.Errorf("Expected empty authorization, got %s", authorizations[0])
+	}
+}
+
+// TestBotNotifyAnnouncement tests that the NotifyAnnouncement function posts a Block Kit message to each channel.
+func TestBotNotifyAnnouncement(t *testing.T) {
+	var messages []slackBlocks.Message
+	var authorizations []string
+	server := newSlackStandIn(t, &messages, &authorizations)
+	defer server.Close()
+	sn := NewSlackBotNotifier("xoxb-test", []string{"C1", "C2"})
+	sn.SetApiEndpoint(server.URL)
+
+	err := sn.NotifyAnnouncement(exchanges.Announcement{URL: "https://www.google.com", Title: "Test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 2 || messages[0].Channel != "C1" || messages[1].Channel != "C2" {
+		t.Errorf("Expected messages for %v, got %v", []string{"C1", "C2"}, messages)
+	}
+	if authorizations[0] != "Bearer xoxb-test" {
+		t.Errorf("Expected %s, got %s", "Bearer xoxb-test", authorizations[0])
+	}
+}
+
+// TestBotNotifyError tests that Web API errors are returned.
+func TestBotNotifyError(t *testing.T) {
+	var messages []slackBlocks.Message
+	var authorizations []string
+	server := newSlackStandIn(t, &messages, &authorizations)
+	defer server.Close()
+	sn := NewSlackBotNotifier("xoxb-wrong", []string{"C1"})
+	sn.SetApiEndpoint(server.URL)
+
+	err := sn.NotifyAnnouncement(exchanges.Announcement{Title: "Test"})
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -2,34 +2,120 @@
 package telegram
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
-	"github.com/rickstaa/crypto-listings-sniper/utils"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 
-	"github.com/adshao/go-binance/v2"
 	"github.com/mymmrac/telego"
+	"github.com/mymmrac/telego/telegoapi"
 	tu "github.com/mymmrac/telego/telegoutil"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
 	"github.com/rickstaa/crypto-listings-sniper/messaging/telegram/telegramMessages"
 )
 
-// SendTelegramMessage sends a Telegram message to a specified chat.
-func sendTelegramMessage(telegramBot *telego.Bot, chatID int64, message string) {
+// sendTelegramMessage sends a Telegram message to a specified chat.
+func sendTelegramMessage(telegramBot *telego.Bot, chatID int64, message string) error {
 	msg := tu.Message(tu.ID(chatID), message)
 	msg.ParseMode = telego.ModeHTML
 	_, err := telegramBot.SendMessage(msg)
 	if err != nil {
-		log.Printf("WARNING: Error sending message '%s' to channel '%d': %v", msg.Text, chatID, err)
+		err = fmt.Errorf("error sending message '%s' to chat '%d': %w", msg.Text, chatID, err)
+
+		// Return the flood control wait time so that the message can be retried later.
+		var apiErr *telegoapi.Error
+		if errors.As(err, &apiErr) && apiErr.Parameters != nil && apiErr.Parameters.RetryAfter > 0 {
+			return &messaging.RetryAfterError{Err: err, RetryAfter: time.Duration(apiErr.Parameters.RetryAfter) * time.Second}
+		}
+		if apiErr != nil {
+			return messaging.StatusError(apiErr.ErrorCode, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// TelegramNotifier is a class that posts the checker events in Telegram chats. It implements the messaging.Notifier interface.
+type TelegramNotifier struct {
+	telegramBot  *telego.Bot
+	chatID       int64
+	routes       map[string][]int64
+	destinations []string // Chats to post in (all if empty, see WithDestinations).
+}
+
+// NewTelegramNotifier creates a new TelegramNotifier that posts in the given chat.
+func NewTelegramNotifier(telegramBot *telego.Bot, chatID int64) *TelegramNotifier {
+	return &TelegramNotifier{
+		telegramBot: telegramBot,
+		chatID:      chatID,
 	}
 }
 
-// SendAssetTelegramMessage send a new/removed asset Telegram message to a specified chat.
-func SendAssetTelegramMessage(telegramBot *telego.Bot, chatID int64, removed bool, asset string, assetInfo binance.Symbol) {
-	message := telegramMessages.AssetMessage(removed, asset, utils.CreateBinanceURL(asset), assetInfo)
-	sendTelegramMessage(telegramBot, chatID, message)
+// SetRoutes sets the chats in which the announcements of each kind (e.g. exchanges.ANNOUNCEMENT_LISTING) are posted.
+// NOTE: Announcements of kinds without a route are posted in the default chat.
+func (tn *TelegramNotifier) SetRoutes(routes map[string][]int64) {
+	tn.routes = routes
+}
+
+// WithDestinations returns a copy of the notifier that only posts in the given chats. It implements the messaging.DestinationNotifier interface.
+func (tn *TelegramNotifier) WithDestinations(destinations []string) messaging.Notifier {
+	notifier := *tn
+	notifier.destinations = destinations
+	return &notifier
+}
+
+// send sends a Telegram message to the given chats.
+// NOTE: The errors are returned per chat so that only the failed chats are retried.
+func (tn *TelegramNotifier) send(chatIDs []int64, message string) error {
+	var errs []error
+	for _, chatID := range chatIDs {
+		destination := strconv.FormatInt(chatID, 10)
+		if !messaging.HasDestination(tn.destinations, destination) {
+			continue
+		}
+		if err := sendTelegramMessage(tn.telegramBot, chatID, message); err != nil {
+			errs = append(errs, &messaging.DestinationError{Destination: destination, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// chatIDs returns the chats in which the announcements of a given kind are posted.
+func (tn *TelegramNotifier) chatIDs(kind string) []int64 {
+	if chatIDs, ok := tn.routes[kind]; ok {
+		return chatIDs
+	}
+
+	return []int64{tn.chatID}
+}
+
+// Name returns the name of the messaging service.
+func (tn *TelegramNotifier) Name() string {
+	return "Telegram"
+}
+
+// NotifyAsset sends a new/removed asset Telegram message to the default chat.
+func (tn *TelegramNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	message := telegramMessages.AssetMessage(removed, assetInfo)
+	return tn.send([]int64{tn.chatID}, message)
+}
+
+// NotifyAnnouncement sends a announcement Telegram message to the chats of the announcement kind.
+func (tn *TelegramNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	message := telegramMessages.AnnouncementMessage(announcement)
+	return tn.send(tn.chatIDs(announcement.Kind), message)
+}
+
+// NotifyStatus sends a symbol status transition Telegram message to the default chat.
+func (tn *TelegramNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	message := telegramMessages.StatusMessage(transition)
+	return tn.send([]int64{tn.chatID}, message)
 }
 
-// Send a announcement Telegram message to the specified chat.
-func SendAnnouncementTelegramMessage(telegramBot *telego.Bot, chatID int64, announcementCode, announcementTitle string) {
-	message := telegramMessages.AnnouncementMessage(utils.CreateBinanceArticleURL(announcementCode, announcementTitle), announcementTitle)
-	sendTelegramMessage(telegramBot, chatID, message)
+// NotifyTrade sends a closed trade Telegram message to the default chat.
+func (tn *TelegramNotifier) NotifyTrade(trade exchanges.Trade) error {
+	message := telegramMessages.TradeMessage(trade)
+	return tn.send([]int64{tn.chatID}, message)
 }
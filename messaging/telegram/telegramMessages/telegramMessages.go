@@ -3,31 +3,141 @@ package telegramMessages
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
 )
 
 // newAssetMessage returns a new asset Telegram message.
-func newAssetMessage(asset string, url string, symbolInfo binance.Symbol) string {
-	return fmt.Sprintf("💎 <u>Binance listed new asset (<a href='%s'>%s</a>)</u>\n\n", url, asset) +
+func newAssetMessage(symbolInfo exchanges.SymbolInfo) string {
+	message := fmt.Sprintf("💎 <u>%s listed new asset (<a href='%s'>%s</a>)</u>\n\n", symbolInfo.Exchange, symbolInfo.URL, symbolInfo.Symbol) +
 		fmt.Sprintf("- <b>Base Asset:</b> %s\n", symbolInfo.BaseAsset) +
 		fmt.Sprintf("- <b>Quota Asset:</b> %s\n", symbolInfo.QuoteAsset)
+	if symbolInfo.Status != "" {
+		message += fmt.Sprintf("- <b>Status:</b> %s\n", symbolInfo.Status)
+	}
+	return message
+}
+
+// newFuturesContractMessage returns a new futures contract Telegram message.
+func newFuturesContractMessage(symbolInfo exchanges.SymbolInfo) string {
+	message := fmt.Sprintf("📈 <u>%s listed new futures contract (<a href='%s'>%s</a>)</u>\n\n", symbolInfo.Exchange, symbolInfo.URL, symbolInfo.Symbol) +
+		fmt.Sprintf("- <b>Base Asset:</b> %s\n", symbolInfo.BaseAsset) +
+		fmt.Sprintf("- <b>Quota Asset:</b> %s\n", symbolInfo.QuoteAsset) +
+		fmt.Sprintf("- <b>Contract Type:</b> %s\n", symbolInfo.ContractType)
+	if !symbolInfo.OnboardDate.IsZero() {
+		message += fmt.Sprintf("- <b>Onboard Date:</b> %s\n", symbolInfo.OnboardDate.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	if symbolInfo.Status != "" {
+		message += fmt.Sprintf("- <b>Status:</b> %s\n", symbolInfo.Status)
+	}
+	return message
+}
+
+// newProductAssetMessage returns a new product asset Telegram message.
+func newProductAssetMessage(symbolInfo exchanges.SymbolInfo) string {
+	message := fmt.Sprintf("🏦 <u>%s added new asset (<a href='%s'>%s</a>)</u>\n\n", symbolInfo.Exchange, symbolInfo.URL, symbolInfo.Symbol) +
+		fmt.Sprintf("- <b>Base Asset:</b> %s\n", symbolInfo.BaseAsset)
+	if symbolInfo.QuoteAsset != "" {
+		message += fmt.Sprintf("- <b>Quota Asset:</b> %s\n", symbolInfo.QuoteAsset)
+	}
+	if symbolInfo.Status != "" {
+		message += fmt.Sprintf("- <b>Status:</b> %s\n", symbolInfo.Status)
+	}
+	return message
 }
 
 // removedAssetMessage return a removed asset Telegram message.
-func removedAssetMessage(asset string) string {
-	return fmt.Sprintf("🗑 <u>Binance removed asset (%s)</u>\n", asset)
+func removedAssetMessage(symbolInfo exchanges.SymbolInfo) string {
+	return fmt.Sprintf("🗑 <u>%s removed asset (%s)</u>\n", symbolInfo.Exchange, symbolInfo.Symbol)
 }
 
 // AssetMessage returns a string containing new/removed asset Telegram message.
-func AssetMessage(removed bool, asset string, url string, assetInfo binance.Symbol) string {
+func AssetMessage(removed bool, assetInfo exchanges.SymbolInfo) string {
 	if removed {
-		return removedAssetMessage(asset)
+		return removedAssetMessage(assetInfo)
+	}
+	if assetInfo.IsFuturesContract() {
+		return newFuturesContractMessage(assetInfo)
+	}
+	if assetInfo.IsProduct() {
+		return newProductAssetMessage(assetInfo)
 	}
-	return newAssetMessage(asset, url, assetInfo)
+	return newAssetMessage(assetInfo)
 }
 
 // Returns a string containing a message for a new announcement.
-func AnnouncementMessage(url string, title string) string {
-	return fmt.Sprintf("📢 <a href='%s'>%s</a>\n", url, title)
+// NOTE: The structured fields are only added when they were extracted from the title.
+func AnnouncementMessage(announcement exchanges.Announcement) string {
+	message := fmt.Sprintf("📢 <a href='%s'>%s</a>\n", announcement.URL, announcement.Title)
+	if announcement.CatalogName != "" {
+		message = fmt.Sprintf("📢 <b>[%s]</b> <a href='%s'>%s</a>\n", announcement.CatalogName, announcement.URL, announcement.Title)
+	}
+	if announcement.Kind != "" && announcement.Kind != exchanges.ANNOUNCEMENT_OTHER {
+		message += fmt.Sprintf("\n- <b>Kind:</b> %s\n", announcement.Kind)
+	}
+	if len(announcement.Tickers) != 0 {
+		message += fmt.Sprintf("- <b>Tickers:</b> %s\n", strings.Join(announcement.Tickers, ", "))
+	}
+	if len(announcement.Pairs) != 0 {
+		message += fmt.Sprintf("- <b>Pairs:</b> %s\n", strings.Join(announcement.Pairs, ", "))
+	}
+	if len(announcement.Dates) != 0 {
+		message += fmt.Sprintf("- <b>Dates:</b> %s\n", strings.Join(announcement.Dates, ", "))
+	}
+	return message
+}
+
+// statusTitle returns the title of a symbol status transition message.
+func statusTitle(transition exchanges.StatusTransition) string {
+	switch {
+	case transition.OldStatus == "" && transition.NewStatus == exchanges.STATUS_PRE_TRADING:
+		return fmt.Sprintf("🔜 %s added pre-trading asset", transition.Exchange)
+	case transition.OldStatus == exchanges.STATUS_PRE_TRADING && transition.NewStatus == exchanges.STATUS_TRADING:
+		return fmt.Sprintf("🚀 %s opened trading", transition.Exchange)
+	case transition.NewStatus == exchanges.STATUS_BREAK:
+		return fmt.Sprintf("⏸ %s halted trading", transition.Exchange)
+	case transition.OldStatus == exchanges.STATUS_BREAK && transition.NewStatus == exchanges.STATUS_TRADING:
+		return fmt.Sprintf("▶️ %s resumed trading", transition.Exchange)
+	default:
+		return fmt.Sprintf("🔄 %s changed trading status", transition.Exchange)
+	}
+}
+
+// StatusMessage returns a string containing a symbol status transition message.
+func StatusMessage(transition exchanges.StatusTransition) string {
+	oldStatus := transition.OldStatus
+	if oldStatus == "" {
+		oldStatus = "NONE"
+	}
+	return fmt.Sprintf("<u>%s (<a href='%s'>%s</a>)</u>\n\n", statusTitle(transition), transition.URL, transition.Symbol) +
+		fmt.Sprintf("- <b>Status:</b> %s → %s\n", oldStatus, transition.NewStatus) +
+		fmt.Sprintf("- <b>Time:</b> %s\n", transition.Time.UTC().Format("2006-01-02 15:04:05 MST"))
+}
+
+// tradeTitle returns the title of a closed trade message.
+// NOTE: Simulated trades are marked as paper positions.
+func tradeTitle(trade exchanges.Trade) string {
+	position := "position"
+	if trade.Simulated {
+		position = "paper position"
+	}
+	if trade.PnL < 0 {
+		return fmt.Sprintf("🔻 %s closed %s with loss", trade.Exchange, position)
+	}
+	return fmt.Sprintf("💰 %s closed %s with profit", trade.Exchange, position)
+}
+
+// TradeMessage returns a string containing a closed trade message with the fill prices and PnL.
+func TradeMessage(trade exchanges.Trade) string {
+	return fmt.Sprintf("<u>%s (<a href='%s'>%s</a>)</u>\n\n", tradeTitle(trade), trade.URL, trade.Symbol) +
+		fmt.Sprintf("- <b>Quantity:</b> %s %s\n", utils.FormatAmount(trade.Quantity), trade.BaseAsset) +
+		fmt.Sprintf("- <b>Entry Price:</b> %s %s\n", utils.FormatAmount(trade.EntryPrice), trade.QuoteAsset) +
+		fmt.Sprintf("- <b>Exit Price:</b> %s %s\n", utils.FormatAmount(trade.ExitPrice), trade.QuoteAsset) +
+		fmt.Sprintf("- <b>Fees:</b> %s %s\n", utils.FormatAmount(trade.Fees), trade.QuoteAsset) +
+		fmt.Sprintf("- <b>PnL:</b> %s %s (%+.2f%%)\n", utils.FormatAmount(trade.PnL), trade.QuoteAsset, trade.PnLPercentage()) +
+		fmt.Sprintf("- <b>Exit Reason:</b> %s\n", strings.ReplaceAll(trade.ExitReason, "_", " ")) +
+		fmt.Sprintf("- <b>Holding Time:</b> %v\n", trade.ExitTime.Sub(trade.EntryTime).Round(time.Second))
 }
@@ -4,21 +4,48 @@ package telegramMessages
 
 import (
 	"testing"
+	"time"
 
-	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
 )
 
 // TestNewAssetMessage tests the newAssetMessage function.
 func TestNewAssetMessage(t *testing.T) {
-	message := newAssetMessage("BTC", "https://www.google.com", binance.Symbol{BaseAsset: "BTC", QuoteAsset: "USDT"})
+	message := newAssetMessage(exchanges.SymbolInfo{Exchange: "Binance", Symbol: "BTC", URL: "https://www.google.com", BaseAsset: "BTC", QuoteAsset: "USDT"})
 	if message != "💎 <u>Binance listed new asset (<a href='https://www.google.com'>BTC</a>)</u>\n\n- <b>Base Asset:</b> BTC\n- <b>Quota Asset:</b> USDT\n" {
 		t.Errorf("Expected %s, got %s", "💎 <u>Binance listed new asset (<a href='https://www.google.com'>BTC</a>)</u>\n\n- <b>Base Asset:</b> BTC\n- <b>Quota Asset:</b> USDT\n", message)
 	}
 }
 
+// TestNewAssetMessageStatus tests the newAssetMessage function with a symbol status.
+func TestNewAssetMessageStatus(t *testing.T) {
+	message := newAssetMessage(exchanges.SymbolInfo{Exchange: "Coinbase", Symbol: "BTC-USD", URL: "https://www.google.com", BaseAsset: "BTC", QuoteAsset: "USD", Status: "online"})
+	if message != "💎 <u>Coinbase listed new asset (<a href='https://www.google.com'>BTC-USD</a>)</u>\n\n- <b>Base Asset:</b> BTC\n- <b>Quota Asset:</b> USD\n- <b>Status:</b> online\n" {
+		t.Errorf("Expected %s, got %s", "💎 <u>Coinbase listed new asset (<a href='https://www.google.com'>BTC-USD</a>)</u>\n\n- <b>Base Asset:</b> BTC\n- <b>Quota Asset:</b> USD\n- <b>Status:</b> online\n", message)
+	}
+}
+
+// TestAssetMessageFuturesContract tests that the AssetMessage function returns a futures contract message for futures contracts.
+func TestAssetMessageFuturesContract(t *testing.T) {
+	expected := "📈 <u>Binance USD-M Futures listed new futures contract (<a href='https://www.google.com'>FOOUSDT</a>)</u>\n\n- <b>Base Asset:</b> FOO\n- <b>Quota Asset:</b> USDT\n- <b>Contract Type:</b> PERPETUAL\n- <b>Onboard Date:</b> 2024-03-01 08:00 UTC\n- <b>Status:</b> PENDING_TRADING\n"
+	message := AssetMessage(false, exchanges.SymbolInfo{Exchange: "Binance USD-M Futures", Symbol: "FOOUSDT", URL: "https://www.google.com", BaseAsset: "FOO", QuoteAsset: "USDT", Status: "PENDING_TRADING", ContractType: "PERPETUAL", OnboardDate: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)})
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+}
+
+// TestAssetMessageProduct tests that the AssetMessage function returns a product asset message for product assets.
+func TestAssetMessageProduct(t *testing.T) {
+	expected := "🏦 <u>Binance Cross Margin added new asset (<a href='https://www.google.com'>FOO</a>)</u>\n\n- <b>Base Asset:</b> FOO\n- <b>Status:</b> BORROWABLE\n"
+	message := AssetMessage(false, exchanges.SymbolInfo{Exchange: "Binance Cross Margin", Symbol: "FOO", URL: "https://www.google.com", BaseAsset: "FOO", Status: "BORROWABLE", Product: exchanges.PRODUCT_CROSS_MARGIN})
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+}
+
 // TestRemovedAssetMessage tests the removedAssetMessage function.
 func TestRemovedAssetMessage(t *testing.T) {
-	message := removedAssetMessage("BTC")
+	message := removedAssetMessage(exchanges.SymbolInfo{Exchange: "Binance", Symbol: "BTC"})
 	if message != "🗑 <u>Binance removed asset (BTC)</u>\n" {
 		t.Errorf("Expected %s, got %s", "🗑 <u>Binance removed asset (BTC)</u>\n", message)
 	}
@@ -26,8 +53,61 @@ func TestRemovedAssetMessage(t *testing.T) {
 
 // TestAnnouncementMessage tests the AnnouncementMessage function.
 func TestAnnouncementMessage(t *testing.T) {
-	message := AnnouncementMessage("https://www.google.com", "test")
+	message := AnnouncementMessage(exchanges.Announcement{URL: "https://www.google.com", Title: "test"})
 	if message != "📢 <a href='https://www.google.com'>test</a>\n" {
 		t.Errorf("Expected %s, got %s", "📢 <a href='https://www.google.com'>test</a>\n", message)
 	}
 }
+
+// TestAnnouncementMessageFields tests the AnnouncementMessage function with structured fields.
+func TestAnnouncementMessageFields(t *testing.T) {
+	message := AnnouncementMessage(exchanges.Announcement{URL: "https://www.google.com", Title: "test", Kind: "listing", Tickers: []string{"FOO", "BAR"}, Pairs: []string{"FOO/USDT"}, Dates: []string{"2023-05-15"}})
+	expected := "📢 <a href='https://www.google.com'>test</a>\n\n- <b>Kind:</b> listing\n- <b>Tickers:</b> FOO, BAR\n- <b>Pairs:</b> FOO/USDT\n- <b>Dates:</b> 2023-05-15\n"
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+}
+
+// TestAnnouncementMessageCatalog tests that the AnnouncementMessage function tags the message with the catalog name.
+func TestAnnouncementMessageCatalog(t *testing.T) {
+	message := AnnouncementMessage(exchanges.Announcement{URL: "https://www.google.com", Title: "test", CatalogName: "Delisting"})
+	expected := "📢 <b>[Delisting]</b> <a href='https://www.google.com'>test</a>\n"
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+}
+
+// TestStatusMessage tests the StatusMessage function.
+func TestStatusMessage(t *testing.T) {
+	transition := exchanges.StatusTransition{Exchange: "Binance", Symbol: "FOOUSDT", OldStatus: "PRE_TRADING", NewStatus: "TRADING", Time: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC), URL: "https://www.google.com"}
+	message := StatusMessage(transition)
+	expected := "<u>🚀 Binance opened trading (<a href='https://www.google.com'>FOOUSDT</a>)</u>\n\n- <b>Status:</b> PRE_TRADING → TRADING\n- <b>Time:</b> 2023-05-01 12:00:00 UTC\n"
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+
+	transition.OldStatus = ""
+	transition.NewStatus = "PRE_TRADING"
+	message = StatusMessage(transition)
+	expected = "<u>🔜 Binance added pre-trading asset (<a href='https://www.google.com'>FOOUSDT</a>)</u>\n\n- <b>Status:</b> NONE → PRE_TRADING\n- <b>Time:</b> 2023-05-01 12:00:00 UTC\n"
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+}
+
+// TestTradeMessage tests the TradeMessage function.
+func TestTradeMessage(t *testing.T) {
+	entryTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	trade := exchanges.Trade{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", Quantity: 10, EntryPrice: 1, ExitPrice: 1.5, Fees: 0.025, PnL: 4.975, ExitReason: exchanges.EXIT_TAKE_PROFIT, EntryTime: entryTime, ExitTime: entryTime.Add(90 * time.Second), URL: "https://www.google.com"}
+	message := TradeMessage(trade)
+	expected := "<u>💰 Binance closed position with profit (<a href='https://www.google.com'>FOOUSDT</a>)</u>\n\n- <b>Quantity:</b> 10 FOO\n- <b>Entry Price:</b> 1 USDT\n- <b>Exit Price:</b> 1.5 USDT\n" +
+		"- <b>Fees:</b> 0.025 USDT\n- <b>PnL:</b> 4.975 USDT (+49.75%)\n- <b>Exit Reason:</b> take profit\n- <b>Holding Time:</b> 1m30s\n"
+	if message != expected {
+		t.Errorf("Expected %s, got %s", expected, message)
+	}
+
+	trade.Simulated = true
+	if title := tradeTitle(trade); title != "💰 Binance closed paper position with profit" {
+		t.Errorf("Expected %s, got %s", "💰 Binance closed paper position with profit", title)
+	}
+}
@@ -0,0 +1,20 @@
+// Description: Tests for the telegram package.
+
+package telegram
+
+import (
+	"testing"
+)
+
+// TestChatIDs tests that the TelegramNotifier routes announcement kinds to their chats.
+func TestChatIDs(t *testing.T) {
+	tn := NewTelegramNotifier(nil, 1)
+	tn.SetRoutes(map[string][]int64{"listing": {2, 3}})
+
+	if chatIDs := tn.chatIDs("listing"); len(chatIDs) != 2 || chatIDs[0] != 2 || chatIDs[1] != 3 {
+		t.Errorf("Expected %v, got %v", []int64{2, 3}, chatIDs)
+	}
+	if chatIDs := tn.chatIDs("delisting"); len(chatIDs) != 1 || chatIDs[0] != 1 {
+		t.Errorf("Expected %v, got %v", []int64{1}, chatIDs)
+	}
+}
@@ -0,0 +1,389 @@
+// Description: The webhook package contains a notifier that posts the checker events as signed JSON payloads to user defined webhooks.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/valyala/fasthttp"
+)
+
+// EVENT_VERSION is the version of the webhook event payload.
+// NOTE: Increase when fields are removed or their meaning changes.
+const EVENT_VERSION = 1
+
+// Webhook event types.
+const (
+	EVENT_ASSET_LISTED      = "asset.listed"
+	EVENT_ASSET_REMOVED     = "asset.removed"
+	EVENT_FUTURES_LISTED    = "futures.listed"
+	EVENT_PRODUCT_LISTED    = "product.listed"
+	EVENT_ANNOUNCEMENT      = "announcement.published"
+	EVENT_STATUS_TRANSITION = "status.transition"
+	EVENT_TRADE_CLOSED      = "trade.closed"
+)
+
+// Webhook signature headers.
+// NOTE: The signature is the hex encoded HMAC-SHA256 of '<timestamp>.<body>' using the webhook secret.
+const (
+	SIGNATURE_HEADER           = "X-Signature-256"
+	SIGNATURE_TIMESTAMP_HEADER = "X-Signature-Timestamp"
+	EVENT_ID_HEADER            = "X-Event-ID"
+)
+
+// Default delivery retry settings.
+const (
+	DEFAULT_MAX_RETRIES = 5
+	DEFAULT_MIN_BACKOFF = 1 * time.Second
+	DEFAULT_MAX_BACKOFF = 1 * time.Minute
+	REQUEST_TIMEOUT     = 10 * time.Second
+)
+
+// Event represents a versioned webhook event.
+type Event struct {
+	Version           int        `json:"version"`
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	Exchange          string     `json:"exchange"`
+	Symbol            string     `json:"symbol,omitempty"`
+	BaseAsset         string     `json:"base_asset,omitempty"`
+	QuoteAsset        string     `json:"quote_asset,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	OldStatus         string     `json:"old_status,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	ContractType      string     `json:"contract_type,omitempty"`
+	OnboardDate       *time.Time `json:"onboard_date,omitempty"`
+	Product           string     `json:"product,omitempty"`
+	AnnouncementCode  string     `json:"announcement_code,omitempty"`
+	AnnouncementTitle string     `json:"announcement_title,omitempty"`
+	AnnouncementURL   string     `json:"announcement_url,omitempty"`
+	AnnouncementKind  string     `json:"announcement_kind,omitempty"`
+	Tickers           []string   `json:"tickers,omitempty"`
+	PublishedAt       *time.Time `json:"published_at,omitempty"`
+	Trade             *Trade     `json:"trade,omitempty"`
+	DetectedAt        time.Time  `json:"detected_at"`
+}
+
+// Trade represents the details of a closed trade event.
+type Trade struct {
+	Quantity   float64   `json:"quantity"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitPrice  float64   `json:"exit_price"`
+	Fees       float64   `json:"fees"`
+	PnL        float64   `json:"pnl"`
+	ExitReason string    `json:"exit_reason"`
+	EntryTime  time.Time `json:"entry_time"`
+	ExitTime   time.Time `json:"exit_time"`
+	Simulated  bool      `json:"simulated"`
+}
+
+// DeadLetter represents a event that could not be delivered to a webhook.
+type DeadLetter struct {
+	URL      string    `json:"url"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+	Event    Event     `json:"event"`
+}
+
+// newEventID returns a random event ID for events that are posted without a event ID (see WithEvent).
+func newEventID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(id)
+}
+
+// Sign returns the HMAC-SHA256 signature of a webhook payload.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookNotifier is a class that posts the checker events as signed JSON payloads to webhooks. It implements the messaging.Notifier interface.
+type WebhookNotifier struct {
+	urls            []string
+	secret          string
+	maxRetries      int
+	minBackoff      time.Duration
+	maxBackoff      time.Duration
+	deadLetterPath  string
+	deadLetterMutex *sync.Mutex
+	destinations    []string  // URLs to post to (all if empty, see WithDestinations).
+	eventID         string    // ID of the posted events (random if empty, see WithEvent).
+	detectedAt      time.Time // Detection time of the posted events (current time if zero, see WithEvent).
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier that posts to the given URLs and signs the payloads with the given secret.
+func NewWebhookNotifier(urls []string, secret string, deadLetterPath string) *WebhookNotifier {
+	return &WebhookNotifier{
+		urls:            urls,
+		secret:          secret,
+		maxRetries:      DEFAULT_MAX_RETRIES,
+		minBackoff:      DEFAULT_MIN_BACKOFF,
+		maxBackoff:      DEFAULT_MAX_BACKOFF,
+		deadLetterPath:  deadLetterPath,
+		deadLetterMutex: &sync.Mutex{},
+	}
+}
+
+// WithDestinations returns a copy of the notifier that only posts to the given URLs. It implements the messaging.DestinationNotifier interface.
+func (wn *WebhookNotifier) WithDestinations(destinations []string) messaging.Notifier {
+	notifier := *wn
+	notifier.destinations = destinations
+	return &notifier
+}
+
+// SetRetries sets the maximum number of delivery retries and the backoff between them.
+func (wn *WebhookNotifier) SetRetries(maxRetries int, minBackoff time.Duration, maxBackoff time.Duration) {
+	wn.maxRetries = maxRetries
+	wn.minBackoff = minBackoff
+	wn.maxBackoff = maxBackoff
+}
+
+// WithEvent returns a copy of the notifier that posts the events with the given ID and detection time. It implements the
+// messaging.EventNotifier interface.
+func (wn *WebhookNotifier) WithEvent(eventID string, detectedAt time.Time) messaging.Notifier {
+	notifier := *wn
+	notifier.eventID = eventID
+	notifier.detectedAt = detectedAt
+	return &notifier
+}
+
+// detectionTime returns the detection time of the posted events.
+func (wn *WebhookNotifier) detectionTime() time.Time {
+	if wn.detectedAt.IsZero() {
+		return time.Now().UTC()
+	}
+	return wn.detectedAt.UTC()
+}
+
+// Name returns the name of the messaging service.
+func (wn *WebhookNotifier) Name() string {
+	return "Webhook"
+}
+
+// post posts a signed payload to a webhook.
+func (wn *WebhookNotifier) post(url string, eventID string, body []byte) error {
+	request := fasthttp.AcquireRequest()
+	response := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(request)
+	defer fasthttp.ReleaseResponse(response)
+
+	// Make request.
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.SetRequestURI(url)
+	request.Header.SetMethod("POST")
+	request.Header.SetContentType("application/json")
+	request.Header.SetUserAgent("crypto-listings-sniper")
+	request.Header.Set(EVENT_ID_HEADER, eventID)
+	request.Header.Set(SIGNATURE_TIMESTAMP_HEADER, timestamp)
+	if wn.secret != "" {
+		request.Header.Set(SIGNATURE_HEADER, Sign(wn.secret, timestamp, body))
+	}
+	request.SetBody(body)
+	err := fasthttp.DoTimeout(request, response, REQUEST_TIMEOUT)
+	if err != nil {
+		return err
+	}
+
+	statusCode := response.StatusCode()
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+	return messaging.StatusError(statusCode, fmt.Errorf("webhook returned status code %d", statusCode))
+}
+
+// deliver posts a event to a webhook and retries with exponential backoff if this fails.
+func (wn *WebhookNotifier) deliver(url string, event Event, body []byte) (err error) {
+	backoff := wn.minBackoff
+	for attempt := 0; attempt <= wn.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > wn.maxBackoff {
+				backoff = wn.maxBackoff
+			}
+		}
+
+		err = wn.post(url, event.ID, body)
+		if err == nil {
+			return nil
+		}
+		var permanentErr *messaging.PermanentError
+		if errors.As(err, &permanentErr) {
+			break
+		}
+	}
+
+	// NOTE: Dead-lettered events are not retried by the caller. Events that could not be dead-lettered are returned as retryable error so that
+	// they are not lost.
+	err = fmt.Errorf("error delivering %s event to webhook '%s': %w", event.Type, url, err)
+	storeErr := wn.storeDeadLetter(url, event, err)
+	if storeErr != nil {
+		log.Printf("WARNING: Error storing %s event for webhook '%s' in dead-letter file: %v", event.Type, url, storeErr)
+		return fmt.Errorf("%v (not dead-lettered: %w)", err, storeErr) // NOTE: Does not wrap the delivery error since it may be permanent.
+	}
+	return &messaging.PermanentError{Err: err}
+}
+
+// storeDeadLetter appends a undeliverable event to the dead-letter file.
+func (wn *WebhookNotifier) storeDeadLetter(url string, event Event, deliveryErr error) error {
+	if wn.deadLetterPath == "" {
+		return nil
+	}
+
+	deadLetterJson, err := json.Marshal(DeadLetter{URL: url, Error: deliveryErr.Error(), FailedAt: time.Now(), Event: event})
+	if err != nil {
+		return fmt.Errorf("error marshalling dead letter: %w", err)
+	}
+
+	wn.deadLetterMutex.Lock()
+	defer wn.deadLetterMutex.Unlock()
+	err = os.MkdirAll(filepath.Dir(wn.deadLetterPath), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("error creating dead-letter folder: %w", err)
+	}
+	file, err := os.OpenFile(wn.deadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening dead-letter file '%s': %w", wn.deadLetterPath, err)
+	}
+	_, err = file.Write(append(deadLetterJson, '\n'))
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error writing dead-letter file '%s': %w", wn.deadLetterPath, err)
+	}
+	return file.Close()
+}
+
+// send delivers a event to all webhooks concurrently.
+// NOTE: The errors are returned per webhook so that only the failed webhooks are retried.
+func (wn *WebhookNotifier) send(event Event) error {
+	event.Version = EVENT_VERSION
+	event.ID = wn.eventID
+	if event.ID == "" {
+		event.ID = newEventID()
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s event: %w", event.Type, err)
+	}
+
+	errs := make([]error, len(wn.urls))
+	var wg sync.WaitGroup
+	for i, url := range wn.urls {
+		if !messaging.HasDestination(wn.destinations, url) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			if err := wn.deliver(url, event, body); err != nil {
+				errs[i] = &messaging.DestinationError{Destination: url, Err: err}
+			}
+		}(i, url)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// NotifyAsset posts a new/removed asset event.
+func (wn *WebhookNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	eventType := EVENT_ASSET_LISTED
+	if removed {
+		eventType = EVENT_ASSET_REMOVED
+	} else if assetInfo.IsFuturesContract() {
+		eventType = EVENT_FUTURES_LISTED
+	} else if assetInfo.IsProduct() {
+		eventType = EVENT_PRODUCT_LISTED
+	}
+	event := Event{
+		Type:         eventType,
+		Exchange:     assetInfo.Exchange,
+		Symbol:       assetInfo.Symbol,
+		BaseAsset:    assetInfo.BaseAsset,
+		QuoteAsset:   assetInfo.QuoteAsset,
+		Status:       assetInfo.Status,
+		URL:          assetInfo.URL,
+		ContractType: assetInfo.ContractType,
+		Product:      assetInfo.Product,
+		DetectedAt:   wn.detectionTime(),
+	}
+	if !assetInfo.OnboardDate.IsZero() {
+		onboardDate := assetInfo.OnboardDate.UTC()
+		event.OnboardDate = &onboardDate
+	}
+	return wn.send(event)
+}
+
+// NotifyAnnouncement posts a announcement event.
+func (wn *WebhookNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	event := Event{
+		Type:              EVENT_ANNOUNCEMENT,
+		Exchange:          announcement.Exchange,
+		AnnouncementCode:  announcement.Code,
+		AnnouncementTitle: announcement.Title,
+		AnnouncementURL:   announcement.URL,
+		AnnouncementKind:  announcement.Kind,
+		Tickers:           announcement.Tickers,
+		DetectedAt:        wn.detectionTime(),
+	}
+	if !announcement.PublishDate.IsZero() {
+		publishedAt := announcement.PublishDate.UTC()
+		event.PublishedAt = &publishedAt
+	}
+	return wn.send(event)
+}
+
+// NotifyStatus posts a symbol status transition event.
+func (wn *WebhookNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return wn.send(Event{
+		Type:       EVENT_STATUS_TRANSITION,
+		Exchange:   transition.Exchange,
+		Symbol:     transition.Symbol,
+		Status:     transition.NewStatus,
+		OldStatus:  transition.OldStatus,
+		URL:        transition.URL,
+		DetectedAt: transition.Time.UTC(),
+	})
+}
+
+// NotifyTrade posts a closed trade event.
+func (wn *WebhookNotifier) NotifyTrade(trade exchanges.Trade) error {
+	return wn.send(Event{
+		Type:       EVENT_TRADE_CLOSED,
+		Exchange:   trade.Exchange,
+		Symbol:     trade.Symbol,
+		BaseAsset:  trade.BaseAsset,
+		QuoteAsset: trade.QuoteAsset,
+		URL:        trade.URL,
+		Trade: &Trade{
+			Quantity:   trade.Quantity,
+			EntryPrice: trade.EntryPrice,
+			ExitPrice:  trade.ExitPrice,
+			Fees:       trade.Fees,
+			PnL:        trade.PnL,
+			ExitReason: trade.ExitReason,
+			EntryTime:  trade.EntryTime.UTC(),
+			ExitTime:   trade.ExitTime.UTC(),
+			Simulated:  trade.Simulated,
+		},
+		DetectedAt: trade.ExitTime.UTC(),
+	})
+}
@@ -0,0 +1,160 @@
+// Description: Tests for the webhook package.
+
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+)
+
+// TestNotifyAsset tests that the NotifyAsset function posts a signed versioned event.
+func TestNotifyAsset(t *testing.T) {
+	var event Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature := Sign("secret", r.Header.Get(SIGNATURE_TIMESTAMP_HEADER), body)
+		if r.Header.Get(SIGNATURE_HEADER) != signature {
+			t.Errorf("Expected %s, got %s", signature, r.Header.Get(SIGNATURE_HEADER))
+		}
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if r.Header.Get(EVENT_ID_HEADER) != event.ID {
+			t.Errorf("Expected %s, got %s", event.ID, r.Header.Get(EVENT_ID_HEADER))
+		}
+	}))
+	defer server.Close()
+	wn := NewWebhookNotifier([]string{server.URL}, "secret", "")
+
+	err := wn.NotifyAsset(false, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.Version != EVENT_VERSION || event.Type != EVENT_ASSET_LISTED {
+		t.Errorf("Expected version %d and type %s, got %d and %s", EVENT_VERSION, EVENT_ASSET_LISTED, event.Version, event.Type)
+	}
+	if event.Symbol != "FOOUSDT" || event.BaseAsset != "FOO" || event.QuoteAsset != "USDT" {
+		t.Errorf("Unexpected event: %v", event)
+	}
+	if event.DetectedAt.IsZero() {
+		t.Errorf("Expected detected at to be set")
+	}
+}
+
+// TestNotifyEvent tests that a retried event is posted with the same ID and detection time.
+func TestNotifyEvent(t *testing.T) {
+	var events []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		events = append(events, event)
+	}))
+	defer server.Close()
+	detectedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	wn := NewWebhookNotifier([]string{server.URL}, "secret", "").WithEvent("event-1", detectedAt)
+
+	for i := 0; i < 2; i++ {
+		if err := wn.NotifyAsset(false, exchanges.SymbolInfo{Exchange: "Binance", Symbol: "FOOUSDT"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	for _, event := range events {
+		if event.ID != "event-1" || !event.DetectedAt.Equal(detectedAt) {
+			t.Errorf("Expected %s and %v, got %s and %v", "event-1", detectedAt, event.ID, event.DetectedAt)
+		}
+	}
+}
+
+// TestNotifyRetry tests that failed deliveries are retried.
+func TestNotifyRetry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	wn := NewWebhookNotifier([]string{server.URL}, "secret", "")
+	wn.SetRetries(3, time.Millisecond, 10*time.Millisecond)
+
+	err := wn.NotifyAnnouncement(exchanges.Announcement{Exchange: "Binance", Code: "a", Title: "Test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+// TestNotifyDeadLetter tests that undeliverable events are stored in the dead-letter file.
+func TestNotifyDeadLetter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	deadLetterPath := filepath.Join(t.TempDir(), "dead_letters.jsonl")
+	wn := NewWebhookNotifier([]string{server.URL}, "secret", deadLetterPath)
+	wn.SetRetries(3, time.Millisecond, 10*time.Millisecond)
+
+	err := wn.NotifyAnnouncement(exchanges.Announcement{Exchange: "Binance", Code: "a", Title: "Test"})
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if requests != 1 { // NOTE: Client errors are not retried.
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+
+	file, err := os.Open(deadLetterPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		t.Fatalf("Expected a dead letter")
+	}
+	var deadLetter DeadLetter
+	if err := json.Unmarshal(scanner.Bytes(), &deadLetter); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if deadLetter.URL != server.URL || deadLetter.Event.AnnouncementCode != "a" {
+		t.Errorf("Unexpected dead letter: %v", deadLetter)
+	}
+}
+
+// TestNotifyDeadLetterError tests that a event that could not be stored in the dead-letter file is returned as retryable error.
+func TestNotifyDeadLetterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	filePath := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(filePath, nil, 0644)
+	wn := NewWebhookNotifier([]string{server.URL}, "secret", filepath.Join(filePath, "dead_letters.jsonl")) // NOTE: Can not be created.
+
+	err := wn.NotifyAnnouncement(exchanges.Announcement{Exchange: "Binance", Code: "a", Title: "Test"})
+	var permanentErr *messaging.PermanentError
+	var destinationErr *messaging.DestinationError
+	if err == nil || errors.As(err, &permanentErr) {
+		t.Errorf("Expected retryable error, got %v", err)
+	}
+	if !errors.As(err, &destinationErr) || destinationErr.Destination != server.URL {
+		t.Errorf("Expected error for webhook '%s', got %v", server.URL, err)
+	}
+}
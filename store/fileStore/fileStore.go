@@ -0,0 +1,195 @@
+// Description: The fileStore package contains a store that stores the checker state in JSON files. It implements the store.Store interface.
+package fileStore
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+)
+
+// FileStore is a class that stores the checker state in JSON files inside a data folder.
+type FileStore struct {
+	dataPath     string
+	historyMutex sync.Mutex
+}
+
+// NewFileStore creates a new FileStore that stores its files in the given data folder.
+func NewFileStore(dataPath string) *FileStore {
+	return &FileStore{
+		dataPath: dataPath,
+	}
+}
+
+// FilePath returns the path of the file in which the items of a given kind and exchange are stored.
+// NOTE: The Binance items are stored in 'assets_list.json' and 'announcements_list.json' for backwards compatibility. Spaces in the exchange name are replaced with underscores.
+func (fs *FileStore) FilePath(kind string, exchange string) string {
+	fileName := kind + "_list.json"
+	if kind == store.LISTINGS {
+		fileName = "assets_list.json"
+	}
+	if exchange != "" && !strings.EqualFold(exchange, "Binance") {
+		fileName = strings.ReplaceAll(strings.ToLower(exchange), " ", "_") + "_" + fileName
+	}
+
+	return filepath.Join(fs.dataPath, fileName)
+}
+
+// RetrieveItems retrieves the stored items of a given kind and exchange.
+func (fs *FileStore) RetrieveItems(kind string, exchange string) (items []string, err error) {
+	filePath := fs.FilePath(kind, exchange)
+	itemsJson, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading %s from file '%s': %w", kind, filePath, err)
+	}
+
+	err = json.Unmarshal(itemsJson, &items)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s: %w", kind, err)
+	}
+	return items, nil
+}
+
+// StoreItems stores the items of a given kind and exchange.
+// NOTE: The items are written to a temporary file that is renamed afterwards so that a crash can not corrupt the file.
+func (fs *FileStore) StoreItems(kind string, exchange string, items []string) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	// Create data folder.
+	err := os.MkdirAll(fs.dataPath, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("error creating data folder: %w", err)
+	}
+
+	itemsJson, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s: %w", kind, err)
+	}
+
+	// Write items atomically.
+	filePath := fs.FilePath(kind, exchange)
+	tmpFile, err := os.CreateTemp(fs.dataPath, filepath.Base(filePath)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file for '%s': %w", filePath, err)
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write(itemsJson)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing %s to file '%s': %w", kind, filePath, err)
+	}
+	err = os.Rename(tmpFile.Name(), filePath)
+	if err != nil {
+		return fmt.Errorf("error writing %s to file '%s': %w", kind, filePath, err)
+	}
+
+	return nil
+}
+
+// HistoryFilePath returns the path of the JSON lines file in which the announcement history of a given exchange is stored.
+func (fs *FileStore) HistoryFilePath(exchange string) string {
+	return strings.TrimSuffix(fs.FilePath(store.ANNOUNCEMENTS, exchange), "_list.json") + "_history.jsonl"
+}
+
+// readAnnouncementHistory reads the announcement history file of a given exchange.
+// NOTE: Only the first record of each announcement is kept.
+func (fs *FileStore) readAnnouncementHistory(exchange string) (records []store.AnnouncementRecord, err error) {
+	historyFile, err := os.Open(fs.HistoryFilePath(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading announcement history: %w", err)
+	}
+	defer historyFile.Close()
+
+	indices := make(map[string]int)
+	scanner := bufio.NewScanner(historyFile)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record store.AnnouncementRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // NOTE: Skips lines that were only partially written during a crash.
+		}
+		if i, ok := indices[record.Code]; ok {
+			records[i].Sent = records[i].Sent || record.Sent
+			continue
+		}
+		indices[record.Code] = len(records)
+		records = append(records, record)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading announcement history: %w", err)
+	}
+
+	return records, nil
+}
+
+// StoreAnnouncement appends a announcement together with the time it was first seen and whether it was sent to the history file.
+func (fs *FileStore) StoreAnnouncement(announcement exchanges.Announcement, firstSeen time.Time, sent bool) error {
+	fs.historyMutex.Lock()
+	defer fs.historyMutex.Unlock()
+
+	// Create data folder.
+	err := os.MkdirAll(fs.dataPath, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("error creating data folder: %w", err)
+	}
+
+	recordJson, err := json.Marshal(store.AnnouncementRecord{Announcement: announcement, FirstSeen: firstSeen, Sent: sent})
+	if err != nil {
+		return fmt.Errorf("error marshalling announcement '%s': %w", announcement.Code, err)
+	}
+	historyFile, err := os.OpenFile(fs.HistoryFilePath(announcement.Exchange), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error storing announcement '%s': %w", announcement.Code, err)
+	}
+	defer historyFile.Close()
+	_, err = historyFile.Write(append(recordJson, '\n'))
+	if err != nil {
+		return fmt.Errorf("error storing announcement '%s': %w", announcement.Code, err)
+	}
+
+	return nil
+}
+
+// RetrieveAnnouncementHistory retrieves all stored announcements of a given exchange ordered by first seen time.
+func (fs *FileStore) RetrieveAnnouncementHistory(exchange string) ([]store.AnnouncementRecord, error) {
+	fs.historyMutex.Lock()
+	defer fs.historyMutex.Unlock()
+
+	records, err := fs.readAnnouncementHistory(exchange)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].FirstSeen.Before(records[j].FirstSeen)
+	})
+	return records, nil
+}
+
+// Close closes the store.
+func (fs *FileStore) Close() error {
+	return nil
+}
@@ -0,0 +1,90 @@
+// Description: Tests for the fileStore package.
+
+package fileStore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+)
+
+// TestFilePath tests the FilePath function.
+func TestFilePath(t *testing.T) {
+	fs := NewFileStore("data")
+	expected := filepath.Join("data", "assets_list.json")
+	if r := fs.FilePath(store.LISTINGS, "Binance"); r != expected {
+		t.Errorf("Expected %s, got %s", expected, r)
+	}
+	expected = filepath.Join("data", "coinbase_assets_list.json")
+	if r := fs.FilePath(store.LISTINGS, "Coinbase"); r != expected {
+		t.Errorf("Expected %s, got %s", expected, r)
+	}
+	expected = filepath.Join("data", "binance_usd-m_futures_assets_list.json")
+	if r := fs.FilePath(store.LISTINGS, "Binance USD-M Futures"); r != expected {
+		t.Errorf("Expected %s, got %s", expected, r)
+	}
+	expected = filepath.Join("data", "announcements_list.json")
+	if r := fs.FilePath(store.ANNOUNCEMENTS, "Binance"); r != expected {
+		t.Errorf("Expected %s, got %s", expected, r)
+	}
+}
+
+// TestStoreItems tests the StoreItems and RetrieveItems functions.
+func TestStoreItems(t *testing.T) {
+	fs := NewFileStore(filepath.Join(t.TempDir(), "data"))
+
+	// Check that a missing file returns no items.
+	items, err := fs.RetrieveItems(store.LISTINGS, "Binance")
+	if err != nil || len(items) != 0 {
+		t.Errorf("Expected no items and no error, got %v and %v", items, err)
+	}
+
+	err = fs.StoreItems(store.LISTINGS, "Binance", []string{"BTCUSDT", "ETHUSDT"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	items, err = fs.RetrieveItems(store.LISTINGS, "Binance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 2 || items[0] != "BTCUSDT" || items[1] != "ETHUSDT" {
+		t.Errorf("Expected %v, got %v", []string{"BTCUSDT", "ETHUSDT"}, items)
+	}
+
+	// Check that no temporary files are left behind.
+	entries, _ := os.ReadDir(fs.dataPath)
+	if len(entries) != 1 {
+		t.Errorf("Expected 1 file, got %d", len(entries))
+	}
+}
+
+// TestStoreAnnouncement tests the StoreAnnouncement and RetrieveAnnouncementHistory functions.
+func TestStoreAnnouncement(t *testing.T) {
+	fs := NewFileStore(filepath.Join(t.TempDir(), "data"))
+	publishDate := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	announcement := exchanges.Announcement{Exchange: "Binance", ID: 1, Code: "abc", Title: "Binance Will List Foo (FOO)", CatalogID: 48, CatalogName: "New Cryptocurrency Listing", PublishDate: publishDate}
+
+	if err := fs.StoreAnnouncement(announcement, publishDate.Add(2*time.Second), false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := fs.StoreAnnouncement(announcement, publishDate.Add(5*time.Second), true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	records, err := fs.RetrieveAnnouncementHistory("Binance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected length of 1, got %d", len(records))
+	}
+	if records[0].Title != announcement.Title || records[0].CatalogID != 48 || !records[0].PublishDate.Equal(publishDate) {
+		t.Errorf("Unexpected record: %+v", records[0])
+	}
+	if records[0].Latency() != 2*time.Second || !records[0].Sent {
+		t.Errorf("Expected latency of 2s and sent record, got %v and %v", records[0].Latency(), records[0].Sent)
+	}
+}
@@ -0,0 +1,230 @@
+// Description: The sqliteStore package contains a store that stores the checker state in a embedded SQLite database. It implements the store.Store interface.
+package sqliteStore
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+	_ "modernc.org/sqlite"
+)
+
+// SCHEMA contains the SQLite database schema.
+const SCHEMA = `
+CREATE TABLE IF NOT EXISTS items (
+	kind         TEXT    NOT NULL,
+	exchange     TEXT    NOT NULL,
+	item         TEXT    NOT NULL,
+	first_seen   INTEGER NOT NULL,
+	last_changed INTEGER NOT NULL,
+	active       INTEGER NOT NULL DEFAULT 1,
+	PRIMARY KEY (kind, exchange, item)
+);
+CREATE TABLE IF NOT EXISTS announcements (
+	exchange     TEXT    NOT NULL,
+	code         TEXT    NOT NULL,
+	id           INTEGER NOT NULL,
+	title        TEXT    NOT NULL,
+	url          TEXT    NOT NULL,
+	catalog_id   INTEGER NOT NULL,
+	catalog_name TEXT    NOT NULL,
+	publish_date INTEGER NOT NULL,
+	first_seen   INTEGER NOT NULL,
+	sent         INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (exchange, code)
+);`
+
+// SQLiteStore is a class that stores the checker state in a SQLite database.
+// NOTE: Every item is stored with the time it was first seen and the time of the last change of the items it was part of so that a history
+// is kept.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (or creates) the SQLite database at the given path.
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	err := os.MkdirAll(filepath.Dir(dbPath), os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("error creating data folder: %w", err)
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening SQLite database '%s': %w", dbPath, err)
+	}
+	db.SetMaxOpenConns(1) // NOTE: SQLite only supports a single writer.
+
+	// Create tables.
+	for _, pragma := range []string{"PRAGMA journal_mode=WAL", "PRAGMA busy_timeout=5000"} {
+		if _, err = db.Exec(pragma); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("error configuring SQLite database: %w", err)
+		}
+	}
+	_, err = db.Exec(SCHEMA)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error creating SQLite tables: %w", err)
+	}
+
+	// Migrate tables.
+	// NOTE: The 'last_seen' column was renamed since the items are only stored when they change.
+	var hasLastSeen bool
+	err = db.QueryRow("SELECT COUNT(*) > 0 FROM pragma_table_info('items') WHERE name = 'last_seen'").Scan(&hasLastSeen)
+	if err == nil && hasLastSeen {
+		_, err = db.Exec("ALTER TABLE items RENAME COLUMN last_seen TO last_changed")
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error migrating SQLite tables: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// DB returns the underlying database handle.
+func (ss *SQLiteStore) DB() *sql.DB {
+	return ss.db
+}
+
+// RetrieveItems retrieves the active items of a given kind and exchange.
+func (ss *SQLiteStore) RetrieveItems(kind string, exchange string) (items []string, err error) {
+	rows, err := ss.db.Query("SELECT item FROM items WHERE kind = ? AND exchange = ? AND active = 1 ORDER BY first_seen, item", kind, exchange)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s: %w", kind, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item string
+		if err = rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("error retrieving %s: %w", kind, err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// StoreItems stores the items of a given kind and exchange.
+// NOTE: Items that are no longer present are marked inactive instead of being removed.
+func (ss *SQLiteStore) StoreItems(kind string, exchange string, items []string) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error storing %s: %w", kind, err)
+	}
+	defer tx.Rollback()
+
+	// Mark all items inactive and (re)activate the current items.
+	now := time.Now().UnixMilli()
+	_, err = tx.Exec("UPDATE items SET active = 0 WHERE kind = ? AND exchange = ? AND active = 1", kind, exchange)
+	if err != nil {
+		return fmt.Errorf("error storing %s: %w", kind, err)
+	}
+	stmt, err := tx.Prepare(`INSERT INTO items (kind, exchange, item, first_seen, last_changed, active) VALUES (?, ?, ?, ?, ?, 1)
+		ON CONFLICT (kind, exchange, item) DO UPDATE SET last_changed = excluded.last_changed, active = 1`)
+	if err != nil {
+		return fmt.Errorf("error storing %s: %w", kind, err)
+	}
+	defer stmt.Close()
+	for _, item := range items {
+		if _, err = stmt.Exec(kind, exchange, item, now, now); err != nil {
+			return fmt.Errorf("error storing %s: %w", kind, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error storing %s: %w", kind, err)
+	}
+	return nil
+}
+
+// ItemHistory retrieves all (active and inactive) items of a given kind and exchange.
+func (ss *SQLiteStore) ItemHistory(kind string, exchange string) (items []store.Item, err error) {
+	rows, err := ss.db.Query("SELECT item, first_seen, last_changed, active FROM items WHERE kind = ? AND exchange = ? ORDER BY first_seen, item", kind, exchange)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s history: %w", kind, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item string
+		var firstSeen, lastChanged int64
+		var active bool
+		if err = rows.Scan(&item, &firstSeen, &lastChanged, &active); err != nil {
+			return nil, fmt.Errorf("error retrieving %s history: %w", kind, err)
+		}
+		items = append(items, store.Item{
+			Kind:        kind,
+			Exchange:    exchange,
+			Item:        item,
+			FirstSeen:   time.UnixMilli(firstSeen),
+			LastChanged: time.UnixMilli(lastChanged),
+			Active:      active,
+		})
+	}
+	return items, rows.Err()
+}
+
+// unixMilli returns the unix millisecond timestamp of a time or zero if the time is not set.
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixMilli()
+}
+
+// fromUnixMilli returns the time of a unix millisecond timestamp or the zero time if the timestamp is zero.
+func fromUnixMilli(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(t)
+}
+
+// StoreAnnouncement stores a announcement together with the time it was first seen and whether it was sent.
+func (ss *SQLiteStore) StoreAnnouncement(announcement exchanges.Announcement, firstSeen time.Time, sent bool) error {
+	_, err := ss.db.Exec(`INSERT INTO announcements (exchange, code, id, title, url, catalog_id, catalog_name, publish_date, first_seen, sent) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (exchange, code) DO UPDATE SET sent = MAX(sent, excluded.sent)`,
+		announcement.Exchange, announcement.Code, announcement.ID, announcement.Title, announcement.URL, announcement.CatalogID, announcement.CatalogName, unixMilli(announcement.PublishDate), unixMilli(firstSeen), sent)
+	if err != nil {
+		return fmt.Errorf("error storing announcement '%s': %w", announcement.Code, err)
+	}
+
+	return nil
+}
+
+// RetrieveAnnouncementHistory retrieves all stored announcements of a given exchange ordered by first seen time.
+func (ss *SQLiteStore) RetrieveAnnouncementHistory(exchange string) (records []store.AnnouncementRecord, err error) {
+	rows, err := ss.db.Query("SELECT code, id, title, url, catalog_id, catalog_name, publish_date, first_seen, sent FROM announcements WHERE exchange = ? ORDER BY first_seen, code", exchange)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving announcement history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record := store.AnnouncementRecord{Announcement: exchanges.Announcement{Exchange: exchange}}
+		var publishDate, firstSeen int64
+		err = rows.Scan(&record.Code, &record.ID, &record.Title, &record.URL, &record.CatalogID, &record.CatalogName, &publishDate, &firstSeen, &record.Sent)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving announcement history: %w", err)
+		}
+		record.PublishDate = fromUnixMilli(publishDate)
+		record.FirstSeen = fromUnixMilli(firstSeen)
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Close closes the SQLite database.
+func (ss *SQLiteStore) Close() error {
+	return ss.db.Close()
+}
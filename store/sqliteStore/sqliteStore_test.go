@@ -0,0 +1,121 @@
+// Description: Tests for the sqliteStore package.
+
+package sqliteStore
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/store"
+)
+
+// TestStoreItems tests the StoreItems, RetrieveItems and ItemHistory functions.
+func TestStoreItems(t *testing.T) {
+	ss, err := NewSQLiteStore(filepath.Join(t.TempDir(), "data", "state.db"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ss.Close()
+
+	err = ss.StoreItems(store.LISTINGS, "Binance", []string{"BTCUSDT", "ETHUSDT"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	err = ss.StoreItems(store.LISTINGS, "Binance", []string{"BTCUSDT", "FOOUSDT"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check active items.
+	items, err := ss.RetrieveItems(store.LISTINGS, "Binance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 2 || items[0] != "BTCUSDT" || items[1] != "FOOUSDT" {
+		t.Errorf("Expected %v, got %v", []string{"BTCUSDT", "FOOUSDT"}, items)
+	}
+	items, _ = ss.RetrieveItems(store.LISTINGS, "Coinbase")
+	if len(items) != 0 {
+		t.Errorf("Expected length of 0, got %d", len(items))
+	}
+
+	// Check history.
+	history, err := ss.ItemHistory(store.LISTINGS, "Binance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Expected length of 3, got %d", len(history))
+	}
+	for _, item := range history {
+		if item.Item == "ETHUSDT" && item.Active {
+			t.Errorf("Expected ETHUSDT to be inactive")
+		}
+		if item.LastChanged.Before(item.FirstSeen) {
+			t.Errorf("Expected last changed %v to be after first seen %v", item.LastChanged, item.FirstSeen)
+		}
+	}
+}
+
+// TestMigrateLastSeen tests that the 'last_seen' column of a existing database is renamed.
+func TestMigrateLastSeen(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = db.Exec(`CREATE TABLE items (kind TEXT NOT NULL, exchange TEXT NOT NULL, item TEXT NOT NULL, first_seen INTEGER NOT NULL,
+		last_seen INTEGER NOT NULL, active INTEGER NOT NULL DEFAULT 1, PRIMARY KEY (kind, exchange, item));
+		INSERT INTO items VALUES ('listings', 'Binance', 'BTCUSDT', 1000, 2000, 1);`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ss, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ss.Close()
+	history, err := ss.ItemHistory(store.LISTINGS, "Binance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 1 || history[0].LastChanged.UnixMilli() != 2000 {
+		t.Errorf("Expected last changed of %d, got %v", 2000, history)
+	}
+}
+
+// TestStoreAnnouncement tests the StoreAnnouncement and RetrieveAnnouncementHistory functions.
+func TestStoreAnnouncement(t *testing.T) {
+	ss, err := NewSQLiteStore(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer ss.Close()
+	publishDate := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	announcement := exchanges.Announcement{Exchange: "Binance", ID: 1, Code: "abc", Title: "Binance Will List Foo (FOO)", URL: "https://www.google.com", CatalogID: 48, CatalogName: "New Cryptocurrency Listing", PublishDate: publishDate}
+
+	if err = ss.StoreAnnouncement(announcement, publishDate.Add(2*time.Second), false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err = ss.StoreAnnouncement(announcement, publishDate.Add(5*time.Second), true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	records, err := ss.RetrieveAnnouncementHistory("Binance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected length of 1, got %d", len(records))
+	}
+	if records[0].Title != announcement.Title || records[0].URL != announcement.URL || records[0].CatalogName != announcement.CatalogName || !records[0].PublishDate.Equal(publishDate) {
+		t.Errorf("Unexpected record: %+v", records[0])
+	}
+	if records[0].Latency() != 2*time.Second || !records[0].Sent {
+		t.Errorf("Expected latency of 2s and sent record, got %v and %v", records[0].Latency(), records[0].Sent)
+	}
+}
@@ -0,0 +1,63 @@
+// Description: The store package contains the interface that is implemented by the supported state storage backends.
+// Note: Currently a (JSON) file and a SQLite backend are supported.
+
+package store
+
+import (
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// Item kinds that are stored by the checkers.
+const (
+	LISTINGS      = "listings"
+	ANNOUNCEMENTS = "announcements"
+)
+
+// Store is the interface that needs to be implemented by the state storage backends.
+type Store interface {
+	// RetrieveItems retrieves the current items of a given kind (e.g. listings) for a given exchange.
+	RetrieveItems(kind string, exchange string) ([]string, error)
+	// StoreItems stores the current items of a given kind (e.g. listings) for a given exchange.
+	StoreItems(kind string, exchange string, items []string) error
+	// Close closes the store.
+	Close() error
+}
+
+// Item represents a stored item together with the time it was first seen and the time of the last change of the items it was part of.
+// NOTE: The items are only stored when they change so the item may have been seen after its last changed time.
+type Item struct {
+	Kind        string
+	Exchange    string
+	Item        string
+	FirstSeen   time.Time
+	LastChanged time.Time
+	Active      bool
+}
+
+// AnnouncementRecord represents a stored announcement together with the time it was first seen and whether it was sent.
+type AnnouncementRecord struct {
+	exchanges.Announcement
+	FirstSeen time.Time
+	Sent      bool
+}
+
+// Latency returns the time between the publication and the detection of the announcement.
+// NOTE: Returns zero if the publish date is unknown.
+func (ar AnnouncementRecord) Latency() time.Duration {
+	if ar.PublishDate.IsZero() {
+		return 0
+	}
+
+	return ar.FirstSeen.Sub(ar.PublishDate)
+}
+
+// AnnouncementStore is the interface that can be implemented by stores that keep the full announcement history.
+type AnnouncementStore interface {
+	// StoreAnnouncement stores a announcement together with the time it was first seen and whether it was sent.
+	// NOTE: Announcements that are already stored keep their first seen time.
+	StoreAnnouncement(announcement exchanges.Announcement, firstSeen time.Time, sent bool) error
+	// RetrieveAnnouncementHistory retrieves all stored announcements of a given exchange ordered by first seen time.
+	RetrieveAnnouncementHistory(exchange string) ([]AnnouncementRecord, error)
+}
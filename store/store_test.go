@@ -0,0 +1,24 @@
+// Description: Tests for the store package.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// TestLatency tests the Latency function.
+func TestLatency(t *testing.T) {
+	publishDate := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	record := AnnouncementRecord{Announcement: exchanges.Announcement{PublishDate: publishDate}, FirstSeen: publishDate.Add(3 * time.Second)}
+	if r := record.Latency(); r != 3*time.Second {
+		t.Errorf("Expected %v, got %v", 3*time.Second, r)
+	}
+
+	record.PublishDate = time.Time{}
+	if r := record.Latency(); r != 0 {
+		t.Errorf("Expected %v, got %v", 0, r)
+	}
+}
@@ -0,0 +1,214 @@
+package trader
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// Binance API error codes.
+// NOTE: The execution status of a order is unknown when it failed with one of the unknown status codes.
+const (
+	ERROR_CODE_UNKNOWN         = -1000
+	ERROR_CODE_UNEXPECTED_RESP = -1006
+	ERROR_CODE_TIMEOUT         = -1007
+	ERROR_CODE_NO_SUCH_ORDER   = -2013
+)
+
+// Order represents a order of the trader.
+type Order struct {
+	Symbol        string
+	BaseAsset     string
+	QuoteAsset    string
+	Side          binance.SideType
+	Type          binance.OrderType // 'MARKET' or 'LIMIT' (GTC).
+	Quantity      string
+	Price         string // Limit price (empty for market orders).
+	ClientOrderID string // ID that is used to look up the order when its placement failed (empty to let the exchange generate one).
+}
+
+// OCOOrder represents a OCO sell order with a take profit limit leg and a stop loss stop-limit leg.
+type OCOOrder struct {
+	Symbol         string
+	BaseAsset      string
+	QuoteAsset     string
+	Quantity       string
+	Price          string // Take profit limit price.
+	StopPrice      string
+	StopLimitPrice string
+}
+
+// OrderReport represents the state of a placed order.
+type OrderReport struct {
+	OrderID int64
+	Type    binance.OrderType
+	Status  binance.OrderStatusType
+	Price   float64 // Limit price (zero for market orders).
+	Fill
+	Time time.Time
+}
+
+// OCOReport represents a placed OCO order.
+type OCOReport struct {
+	OrderListID int64
+	OrderIDs    []int64
+}
+
+// Executor is the interface that places and tracks the orders of the trader so that the real and simulated orders share the trader logic.
+type Executor interface {
+	Simulated() bool
+	CreateOrder(order Order) (OrderReport, error)
+	GetOrder(symbol string, orderID int64) (OrderReport, error)
+	FindOrder(symbol string, clientOrderID string) (report OrderReport, found bool, err error)
+	CancelOrder(symbol string, orderID int64) error
+	OrderFill(symbol string, baseAsset string, quoteAsset string, orderID int64) (Fill, error)
+	CreateOCO(order OCOOrder) (OCOReport, error)
+	CancelOCO(symbol string, orderListID int64) error
+}
+
+// isRejected returns whether a order was rejected by Binance so that it was certainly not placed.
+// NOTE: Network errors, timeouts and unknown status errors are not rejections since the order may have been placed.
+func isRejected(err error) bool {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case 0, ERROR_CODE_UNKNOWN, ERROR_CODE_UNEXPECTED_RESP, ERROR_CODE_TIMEOUT:
+		return false
+	default:
+		return true
+	}
+}
+
+// responseFill returns the fills of a order response.
+// NOTE: The executed quantities are used when the response contains no fills (i.e. it is not a 'FULL' response).
+func responseFill(response *binance.CreateOrderResponse, baseAsset string, quoteAsset string) (fill Fill) {
+	for _, f := range response.Fills {
+		fill.add(parseFloat(f.Price), parseFloat(f.Quantity), parseFloat(f.Commission), f.CommissionAsset, baseAsset, quoteAsset)
+	}
+	if fill.Quantity == 0 {
+		fill.Quantity = parseFloat(response.ExecutedQuantity)
+		fill.Notional = parseFloat(response.CummulativeQuoteQuantity)
+	}
+	return fill
+}
+
+// BinanceExecutor is a class that places the orders of the trader on Binance.
+type BinanceExecutor struct {
+	BinanceClient *binance.Client
+}
+
+// NewBinanceExecutor creates a new BinanceExecutor.
+func NewBinanceExecutor(binanceClient *binance.Client) *BinanceExecutor {
+	return &BinanceExecutor{
+		BinanceClient: binanceClient,
+	}
+}
+
+// Simulated returns whether the orders are simulated.
+func (be *BinanceExecutor) Simulated() bool {
+	return false
+}
+
+// CreateOrder places a order and returns its fills.
+func (be *BinanceExecutor) CreateOrder(order Order) (report OrderReport, err error) {
+	service := be.BinanceClient.NewCreateOrderService().Symbol(order.Symbol).Side(order.Side).Type(order.Type).Quantity(order.Quantity).
+		NewOrderRespType(binance.NewOrderRespTypeFULL)
+	if order.ClientOrderID != "" {
+		service.NewClientOrderID(order.ClientOrderID)
+	}
+	if order.Type == binance.OrderTypeLimit {
+		service.TimeInForce(binance.TimeInForceTypeGTC).Price(order.Price)
+	}
+	response, err := service.Do(context.Background())
+	if err != nil {
+		return report, err
+	}
+	return OrderReport{
+		OrderID: response.OrderID,
+		Type:    order.Type,
+		Status:  response.Status,
+		Price:   parseFloat(response.Price),
+		Fill:    responseFill(response, order.BaseAsset, order.QuoteAsset),
+		Time:    time.UnixMilli(response.TransactTime),
+	}, nil
+}
+
+// GetOrder retrieves the state of a order.
+// NOTE: The fill does not contain the commission (see OrderFill).
+func (be *BinanceExecutor) GetOrder(symbol string, orderID int64) (report OrderReport, err error) {
+	order, err := be.BinanceClient.NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(context.Background())
+	if err != nil {
+		return report, err
+	}
+	return OrderReport{
+		OrderID: order.OrderID,
+		Type:    order.Type,
+		Status:  order.Status,
+		Price:   parseFloat(order.Price),
+		Fill:    Fill{Quantity: parseFloat(order.ExecutedQuantity), Notional: parseFloat(order.CummulativeQuoteQuantity)},
+		Time:    time.UnixMilli(order.UpdateTime),
+	}, nil
+}
+
+// FindOrder looks up the state of a order by its client order ID.
+func (be *BinanceExecutor) FindOrder(symbol string, clientOrderID string) (report OrderReport, found bool, err error) {
+	order, err := be.BinanceClient.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(context.Background())
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == ERROR_CODE_NO_SUCH_ORDER {
+		return report, false, nil
+	}
+	if err != nil {
+		return report, false, err
+	}
+	return OrderReport{
+		OrderID: order.OrderID,
+		Type:    order.Type,
+		Status:  order.Status,
+		Price:   parseFloat(order.Price),
+		Fill:    Fill{Quantity: parseFloat(order.ExecutedQuantity), Notional: parseFloat(order.CummulativeQuoteQuantity)},
+		Time:    time.UnixMilli(order.UpdateTime),
+	}, true, nil
+}
+
+// CancelOrder cancels the remainder of a order.
+func (be *BinanceExecutor) CancelOrder(symbol string, orderID int64) error {
+	_, err := be.BinanceClient.NewCancelOrderService().Symbol(symbol).OrderID(orderID).Do(context.Background())
+	return err
+}
+
+// OrderFill retrieves the fills of a order including their commission.
+func (be *BinanceExecutor) OrderFill(symbol string, baseAsset string, quoteAsset string, orderID int64) (fill Fill, err error) {
+	trades, err := be.BinanceClient.NewListTradesService().Symbol(symbol).OrderId(orderID).Do(context.Background())
+	if err != nil {
+		return fill, err
+	}
+	for _, trade := range trades {
+		fill.add(parseFloat(trade.Price), parseFloat(trade.Quantity), parseFloat(trade.Commission), trade.CommissionAsset, baseAsset, quoteAsset)
+	}
+	return fill, nil
+}
+
+// CreateOCO places a OCO sell order.
+func (be *BinanceExecutor) CreateOCO(order OCOOrder) (report OCOReport, err error) {
+	oco, err := be.BinanceClient.NewCreateOCOService().Symbol(order.Symbol).Side(binance.SideTypeSell).Quantity(order.Quantity).Price(order.Price).
+		StopPrice(order.StopPrice).StopLimitPrice(order.StopLimitPrice).StopLimitTimeInForce(binance.TimeInForceTypeGTC).Do(context.Background())
+	if err != nil {
+		return report, err
+	}
+	report.OrderListID = oco.OrderListID
+	for _, leg := range oco.Orders {
+		report.OrderIDs = append(report.OrderIDs, leg.OrderID)
+	}
+	return report, nil
+}
+
+// CancelOCO cancels a OCO order.
+func (be *BinanceExecutor) CancelOCO(symbol string, orderListID int64) error {
+	_, err := be.BinanceClient.NewCancelOCOService().Symbol(symbol).OrderListID(orderListID).Do(context.Background())
+	return err
+}
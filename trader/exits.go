@@ -0,0 +1,359 @@
+package trader
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+)
+
+// Exit modes.
+const (
+	EXIT_MODE_OCO      = "oco"      // OCO order with a take profit limit leg and a stop loss stop-limit leg.
+	EXIT_MODE_TRAILING = "trailing" // Market order placed when the trade stream hits the take profit, stop loss or trailing stop.
+)
+
+// tradeStream streams the trade prices of a symbol. The stream is stopped by closing the stop channel and the done channel is closed when it stopped.
+type tradeStream func(symbol string, handler func(price float64), errHandler func(err error)) (doneC, stopC chan struct{}, err error)
+
+// binanceTradeStream streams the trade prices of a symbol from the Binance trade websocket.
+func binanceTradeStream(symbol string, handler func(price float64), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	return binance.WsTradeServe(symbol, func(event *binance.WsTradeEvent) {
+		handler(parseFloat(event.Price))
+	}, errHandler)
+}
+
+// exitReason returns the exit reason for a traded price given the entry price and the highest traded price (empty if no exit target is hit).
+func (c Config) exitReason(entryPrice float64, high float64, price float64) string {
+	switch {
+	case c.TakeProfit > 0 && price >= entryPrice*(1+c.TakeProfit):
+		return exchanges.EXIT_TAKE_PROFIT
+	case c.StopLoss > 0 && price <= entryPrice*(1-c.StopLoss):
+		return exchanges.EXIT_STOP_LOSS
+	case c.TrailingStop > 0 && price <= high*(1-c.TrailingStop):
+		return exchanges.EXIT_TRAILING_STOP
+	}
+	return ""
+}
+
+// isFinal returns whether a order can no longer be filled.
+func isFinal(status binance.OrderStatusType) bool {
+	switch status {
+	case binance.OrderStatusTypeFilled, binance.OrderStatusTypeCanceled, binance.OrderStatusTypeRejected, binance.OrderStatusTypeExpired:
+		return true
+	}
+	return false
+}
+
+// waitForFill waits until a entry order is filled and cancels its remainder if it is not filled within the max wait time.
+func (t *Trader) waitForFill(entry Entry) (Fill, error) {
+	deadline := time.Now().Add(t.config.MaxWait)
+	for {
+		report, err := t.Executor.GetOrder(entry.Symbol, entry.OrderID)
+		if err == nil && isFinal(report.Status) {
+			break
+		}
+		if time.Now().After(deadline) {
+			if err := t.Executor.CancelOrder(entry.Symbol, entry.OrderID); err != nil {
+				log.Printf("WARNING: Error cancelling %s entry order %d: %v", entry.Symbol, entry.OrderID, err)
+			}
+			break
+		}
+		time.Sleep(t.orderPollInterval)
+	}
+	return t.Executor.OrderFill(entry.Symbol, entry.BaseAsset, entry.QuoteAsset, entry.OrderID)
+}
+
+// sell places a market sell order.
+func (t *Trader) sell(entry Entry, quantity string) (Fill, error) {
+	report, err := t.Executor.CreateOrder(Order{
+		Symbol:     entry.Symbol,
+		BaseAsset:  entry.BaseAsset,
+		QuoteAsset: entry.QuoteAsset,
+		Side:       binance.SideTypeSell,
+		Type:       binance.OrderTypeMarket,
+		Quantity:   quantity,
+	})
+	return report.Fill, err
+}
+
+// exitOCO places a OCO sell order at the take profit and stop loss and waits until one of its legs is filled or the max hold time is reached.
+// NOTE: The stop loss leg is a stop-limit order with its limit price at the slippage below the stop price.
+func (t *Trader) exitOCO(entry Entry, quantity float64, quantityString string) (exit Fill, reason string, err error) {
+	_, takeProfit := roundDown(clamp(entry.Price*(1+t.config.TakeProfit), entry.filters.maxPrice), entry.filters.tickSize)
+	stopPrice, stopPriceString := roundDown(entry.Price*(1-t.config.StopLoss), entry.filters.tickSize)
+	_, stopLimitPrice := roundDown(stopPrice*(1-t.config.Slippage), entry.filters.tickSize)
+	oco, err := t.Executor.CreateOCO(OCOOrder{
+		Symbol:         entry.Symbol,
+		BaseAsset:      entry.BaseAsset,
+		QuoteAsset:     entry.QuoteAsset,
+		Quantity:       quantityString,
+		Price:          takeProfit,
+		StopPrice:      stopPriceString,
+		StopLimitPrice: stopLimitPrice,
+	})
+	if err != nil {
+		return exit, "", err
+	}
+	log.Printf("Placed %s OCO exit order %d: take profit at %s, stop loss at %s.", entry.Symbol, oco.OrderListID, takeProfit, stopPriceString)
+
+	// Wait until a leg is filled or the OCO order no longer protects the position.
+	// NOTE: Once a leg is partially filled or canceled the other leg is canceled by Binance, so the rest of the position would be left without a
+	// stop loss.
+	deadline := time.Now().Add(t.config.MaxHoldTime)
+	for t.config.MaxHoldTime <= 0 || time.Now().Before(deadline) {
+		reports := make([]OrderReport, 0, len(oco.OrderIDs))
+		for _, orderID := range oco.OrderIDs {
+			report, err := t.Executor.GetOrder(entry.Symbol, orderID)
+			if err != nil {
+				break
+			}
+			reports = append(reports, report)
+		}
+		if len(reports) == len(oco.OrderIDs) {
+			for _, report := range reports {
+				if report.Status == binance.OrderStatusTypeFilled {
+					exit, err = t.Executor.OrderFill(entry.Symbol, entry.BaseAsset, entry.QuoteAsset, report.OrderID)
+					return exit, ocoExitReason(report), err
+				}
+			}
+			for _, report := range reports {
+				if report.Status == binance.OrderStatusTypePartiallyFilled || isFinal(report.Status) {
+					return t.closeOCO(entry, oco, quantity, reports), ocoExitReason(reports...), nil
+				}
+			}
+		}
+		time.Sleep(t.orderPollInterval)
+	}
+
+	// Sell the position at market.
+	return t.closeOCO(entry, oco, quantity, nil), exchanges.EXIT_MAX_HOLD_TIME, nil
+}
+
+// ocoExitReason returns the exit reason of the OCO legs that were (partially) filled.
+// NOTE: EXIT_OCO_CANCELED is returned when no leg was filled (e.g. the OCO order was canceled outside of the trader).
+func ocoExitReason(reports ...OrderReport) string {
+	for _, report := range reports {
+		filled := report.Quantity > 0 || report.Status == binance.OrderStatusTypeFilled
+		if filled && report.Type == binance.OrderTypeLimitMaker {
+			return exchanges.EXIT_TAKE_PROFIT
+		}
+		if filled {
+			return exchanges.EXIT_STOP_LOSS
+		}
+	}
+	return exchanges.EXIT_OCO_CANCELED
+}
+
+// closeOCO cancels the open legs of a OCO order and sells the quantity that was not filled by its legs at market.
+// NOTE: The OCO order is canceled when no leg reports are given or one of the legs is still open. The failed steps are retried until the
+// position is closed since it is no longer protected by the OCO order (see retryExit).
+func (t *Trader) closeOCO(entry Entry, oco OCOReport, quantity float64, reports []OrderReport) (exit Fill) {
+	open := len(reports) == 0
+	for _, report := range reports {
+		open = open || !isFinal(report.Status)
+	}
+	if open {
+		t.retryExit(entry, quantity, func() error {
+			err := t.Executor.CancelOCO(entry.Symbol, oco.OrderListID)
+			if err != nil && !t.ocoClosed(entry, oco) {
+				return fmt.Errorf("error cancelling OCO exit order %d: %w", oco.OrderListID, err)
+			}
+			return nil
+		})
+	}
+	for _, orderID := range oco.OrderIDs {
+		t.retryExit(entry, quantity, func() error {
+			legFill, err := t.Executor.OrderFill(entry.Symbol, entry.BaseAsset, entry.QuoteAsset, orderID)
+			if err != nil {
+				return fmt.Errorf("error retrieving OCO exit order %d fills: %w", orderID, err)
+			}
+			exit.merge(legFill)
+			return nil
+		})
+	}
+	_, remainder := roundDown(quantity-exit.Quantity, entry.filters.marketStepSize)
+	if parseFloat(remainder) > 0 {
+		t.retryExit(entry, quantity-exit.Quantity, func() error {
+			marketFill, err := t.sell(entry, remainder)
+			if err != nil {
+				return fmt.Errorf("error placing market exit order: %w", err)
+			}
+			exit.merge(marketFill)
+			return nil
+		})
+	}
+	return exit
+}
+
+// ocoClosed returns whether all legs of a OCO order can no longer be filled (e.g. when it was filled while it was being canceled).
+func (t *Trader) ocoClosed(entry Entry, oco OCOReport) bool {
+	for _, orderID := range oco.OrderIDs {
+		report, err := t.Executor.GetOrder(entry.Symbol, orderID)
+		if err != nil || !isFinal(report.Status) {
+			return false
+		}
+	}
+	return true
+}
+
+// retryExit retries a step of closing a position until it succeeds since the position is not protected while it fails.
+// NOTE: The notifier is alerted once that manual intervention may be required when the step fails.
+func (t *Trader) retryExit(entry Entry, openQuantity float64, step func() error) {
+	retryPolicy := exchanges.NewRetryPolicy(t.orderPollInterval, EXIT_MAX_BACKOFF)
+	alerted := false
+	for {
+		err := step()
+		if err == nil {
+			return
+		}
+		log.Printf("WARNING: Error closing %s position (retrying): %v", entry.Symbol, err)
+		if !alerted && t.Notifier != nil {
+			t.Notifier.NotifyTrade(exchanges.Trade{
+				Exchange:   "Binance",
+				Symbol:     entry.Symbol,
+				BaseAsset:  entry.BaseAsset,
+				QuoteAsset: entry.QuoteAsset,
+				Quantity:   openQuantity,
+				EntryPrice: entry.Price,
+				ExitReason: exchanges.EXIT_MANUAL_INTERVENTION,
+				EntryTime:  entry.Time,
+				ExitTime:   time.Now(),
+				URL:        utils.CreateBinanceURL(entry.Symbol),
+				Simulated:  t.Executor.Simulated(),
+			})
+			alerted = true
+		}
+		time.Sleep(retryPolicy.Failure(err))
+	}
+}
+
+// exitTrailing follows the trade stream until the take profit, stop loss, trailing stop or max hold time is hit and sells at market.
+// NOTE: The stream is reconnected with backoff when it is closed by Binance and the market sell is retried until it succeeds (see retryExit).
+func (t *Trader) exitTrailing(entry Entry, quantity float64, quantityString string) (exit Fill, reason string, err error) {
+	prices := make(chan float64)
+	stopped := make(chan struct{})
+	defer close(stopped)
+	handler := func(price float64) {
+		select {
+		case prices <- price:
+		case <-stopped:
+		}
+	}
+	errHandler := func(err error) {
+		log.Printf("WARNING: Error streaming %s trades: %v", entry.Symbol, err)
+	}
+	var timeout <-chan time.Time
+	if t.config.MaxHoldTime > 0 {
+		timer := time.NewTimer(t.config.MaxHoldTime)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	// Follow the traded prices.
+	high := entry.Price
+	retryPolicy := exchanges.NewRetryPolicy(t.orderPollInterval, EXIT_MAX_BACKOFF)
+	for reason == "" {
+		doneC, stopC, err := t.tradeStream(entry.Symbol, handler, errHandler)
+		if err != nil {
+			log.Printf("WARNING: Error connecting to %s trade stream: %v", entry.Symbol, err)
+		} else {
+		stream:
+			for reason == "" {
+				select {
+				case price := <-prices:
+					retryPolicy.Success()
+					high = math.Max(high, price)
+					reason = t.config.exitReason(entry.Price, high, price)
+				case <-timeout:
+					reason = exchanges.EXIT_MAX_HOLD_TIME
+				case <-doneC:
+					err = errors.New("trade stream closed")
+					break stream
+				}
+			}
+			close(stopC)
+		}
+		if reason == "" {
+			select {
+			case <-timeout:
+				reason = exchanges.EXIT_MAX_HOLD_TIME
+			case <-time.After(retryPolicy.Failure(err)):
+			}
+		}
+	}
+
+	t.retryExit(entry, quantity, func() error {
+		exit, err = t.sell(entry, quantityString)
+		if err != nil {
+			return fmt.Errorf("error placing market exit order: %w", err)
+		}
+		return nil
+	})
+	return exit, reason, nil
+}
+
+// Exit waits until a entry order is filled, manages the exit of the position with the configured exit mode and returns the closed trade.
+// NOTE: The sold quantity is reduced by the entry commission that was paid in the base asset.
+func (t *Trader) Exit(entry Entry) (trade exchanges.Trade, err error) {
+	if entry.Status != string(binance.OrderStatusTypeFilled) {
+		if entry.Fill, err = t.waitForFill(entry); err != nil {
+			return trade, err
+		}
+	}
+	if entry.Quantity <= 0 {
+		// NOTE: The symbol can be entered again since no position was opened.
+		t.enteredMutex.Lock()
+		delete(t.entered, entry.Symbol)
+		t.enteredMutex.Unlock()
+		return trade, fmt.Errorf("entry order %d was not filled", entry.OrderID)
+	}
+	entry.Price = entry.AveragePrice()
+
+	// Exit position.
+	// NOTE: The OCO legs are limit orders that are rounded to the 'LOT_SIZE' filter while the trailing exit is a market order that is rounded to
+	// the 'MARKET_LOT_SIZE' filter.
+	stepSize, minQuantity := entry.filters.stepSize, entry.filters.minQuantity
+	if t.config.ExitMode == EXIT_MODE_TRAILING {
+		stepSize, minQuantity = entry.filters.marketStepSize, entry.filters.marketMinQty
+	}
+	quantity, quantityString := roundDown(entry.Quantity-entry.BaseFees, stepSize)
+	if quantity <= 0 || quantity < minQuantity {
+		return trade, fmt.Errorf("position quantity %s below the minimum quantity %s", quantityString, utils.FormatAmount(minQuantity))
+	}
+	var exit Fill
+	var reason string
+	switch t.config.ExitMode {
+	case EXIT_MODE_OCO:
+		exit, reason, err = t.exitOCO(entry, quantity, quantityString)
+	case EXIT_MODE_TRAILING:
+		exit, reason, err = t.exitTrailing(entry, quantity, quantityString)
+	default:
+		err = fmt.Errorf("unknown exit mode '%s'", t.config.ExitMode)
+	}
+	if err != nil {
+		return trade, err
+	}
+
+	return exchanges.Trade{
+		Exchange:   "Binance",
+		Symbol:     entry.Symbol,
+		BaseAsset:  entry.BaseAsset,
+		QuoteAsset: entry.QuoteAsset,
+		Quantity:   exit.Quantity,
+		EntryPrice: entry.Price,
+		ExitPrice:  exit.AveragePrice(),
+		Fees:       entry.Fees() + exit.Fees(),
+		PnL:        exit.Notional - exit.QuoteFees - entry.Notional - entry.QuoteFees,
+		ExitReason: reason,
+		EntryTime:  entry.Time,
+		ExitTime:   time.Now(),
+		URL:        utils.CreateBinanceURL(entry.Symbol),
+		Simulated:  t.Executor.Simulated(),
+	}, nil
+}
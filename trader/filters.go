@@ -0,0 +1,80 @@
+package trader
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+)
+
+// symbolFilters contains the parsed Binance trading filters of a symbol that are used to round the orders.
+type symbolFilters struct {
+	stepSize       string
+	minQuantity    float64
+	maxQuantity    float64
+	marketStepSize string
+	marketMinQty   float64
+	marketMaxQty   float64
+	tickSize       string
+	maxPrice       float64
+	minNotional    float64
+}
+
+// parseFloat parses a Binance filter value (zero if empty or invalid).
+func parseFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
+
+// newSymbolFilters parses the 'LOT_SIZE', 'MARKET_LOT_SIZE', 'PRICE_FILTER' and 'NOTIONAL' (or 'MIN_NOTIONAL') filters of a symbol.
+// NOTE: The 'LOT_SIZE' filter is used for market orders when the 'MARKET_LOT_SIZE' filter has no step size.
+func newSymbolFilters(symbol binance.Symbol) (filters symbolFilters, err error) {
+	lotSize := symbol.LotSizeFilter()
+	if lotSize == nil {
+		return filters, fmt.Errorf("symbol '%s' has no LOT_SIZE filter", symbol.Symbol)
+	}
+	filters.stepSize = lotSize.StepSize
+	filters.minQuantity = parseFloat(lotSize.MinQuantity)
+	filters.maxQuantity = parseFloat(lotSize.MaxQuantity)
+	filters.marketStepSize, filters.marketMinQty, filters.marketMaxQty = filters.stepSize, filters.minQuantity, filters.maxQuantity
+	if marketLotSize := symbol.MarketLotSizeFilter(); marketLotSize != nil && parseFloat(marketLotSize.StepSize) > 0 {
+		filters.marketStepSize = marketLotSize.StepSize
+		filters.marketMinQty = parseFloat(marketLotSize.MinQuantity)
+		filters.marketMaxQty = parseFloat(marketLotSize.MaxQuantity)
+	}
+	if priceFilter := symbol.PriceFilter(); priceFilter != nil {
+		filters.tickSize = priceFilter.TickSize
+		filters.maxPrice = parseFloat(priceFilter.MaxPrice)
+	}
+	if notional := symbol.NotionalFilter(); notional != nil {
+		filters.minNotional = parseFloat(notional.MinNotional)
+	} else if minNotional := symbol.MinNotionalFilter(); minNotional != nil {
+		filters.minNotional = parseFloat(minNotional.MinNotional)
+	}
+	return filters, nil
+}
+
+// roundDown rounds a value down to a multiple of a Binance filter step (e.g. '0.01000000') and formats it with the precision of the step.
+// NOTE: The value is only formatted if the step is zero (i.e. the filter is disabled).
+func roundDown(value float64, step string) (float64, string) {
+	stepValue := parseFloat(step)
+	if stepValue <= 0 {
+		return value, strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	precision := 0
+	if i := strings.IndexByte(step, '.'); i >= 0 {
+		precision = len(strings.TrimRight(step[i+1:], "0"))
+	}
+	rounded := math.Floor(value/stepValue+1e-9) * stepValue // NOTE: Prevents float errors from rounding down a exact multiple.
+	return rounded, strconv.FormatFloat(rounded, 'f', precision, 64)
+}
+
+// clamp limits a value to a maximum (ignored if zero).
+func clamp(value float64, max float64) float64 {
+	if max > 0 && value > max {
+		return max
+	}
+	return value
+}
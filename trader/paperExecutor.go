@@ -0,0 +1,298 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+)
+
+// PAPER_FEE_RATE is the default simulated commission rate (i.e. the Binance spot taker fee without BNB discount).
+const PAPER_FEE_RATE = 0.001
+
+// OrderBook returns the bids and asks of a symbol against which the paper orders are filled.
+type OrderBook func(symbol string) (bids []binance.Bid, asks []binance.Ask, err error)
+
+// LiveOrderBook returns a order book that retrieves the live Binance order book of a symbol.
+func LiveOrderBook(binanceClient *binance.Client) OrderBook {
+	return func(symbol string) ([]binance.Bid, []binance.Ask, error) {
+		depth, err := binanceClient.NewDepthService().Symbol(symbol).Limit(DEPTH_LIMIT).Do(context.Background())
+		if err != nil {
+			return nil, nil, err
+		}
+		return depth.Bids, depth.Asks, nil
+	}
+}
+
+// ReplayOrderBook returns a order book that replays recorded order book snapshots in order. The last snapshot is repeated once all snapshots
+// were replayed.
+func ReplayOrderBook(snapshots []binance.DepthResponse) OrderBook {
+	var mutex sync.Mutex
+	return func(symbol string) ([]binance.Bid, []binance.Ask, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(snapshots) == 0 {
+			return nil, nil, fmt.Errorf("no order book snapshots to replay for '%s'", symbol)
+		}
+		snapshot := snapshots[0]
+		if len(snapshots) > 1 {
+			snapshots = snapshots[1:]
+		}
+		return snapshot.Bids, snapshot.Asks, nil
+	}
+}
+
+// paperOrder represents a simulated order.
+type paperOrder struct {
+	order       Order
+	report      OrderReport
+	stopPrice   float64 // Stop price of a stop loss leg (zero if the order has no stop).
+	triggered   bool
+	orderListID int64
+}
+
+// PaperExecutor is a class that simulates the orders of the trader against a order book instead of placing them on Binance.
+// NOTE: Orders fill at the book prices when they are placed (or triggered) and at their limit price when the book crosses them while
+// resting. Buy commission is charged in the base asset and sell commission in the quote asset like on Binance. The order book is retrieved
+// before the mutex is locked so that the other orders are not blocked by its requests.
+type PaperExecutor struct {
+	OrderBook  OrderBook
+	feeRate    float64
+	orders     map[int64]*paperOrder
+	orderLists map[int64][]int64
+	lastID     int64
+	mutex      sync.Mutex
+}
+
+// NewPaperExecutor creates a new PaperExecutor that fills the orders against a given order book with a given commission rate.
+func NewPaperExecutor(orderBook OrderBook, feeRate float64) *PaperExecutor {
+	return &PaperExecutor{
+		OrderBook:  orderBook,
+		feeRate:    feeRate,
+		orders:     make(map[int64]*paperOrder),
+		orderLists: make(map[int64][]int64),
+	}
+}
+
+// Simulated returns whether the orders are simulated.
+func (pe *PaperExecutor) Simulated() bool {
+	return true
+}
+
+// nextID returns a new order or order list ID.
+func (pe *PaperExecutor) nextID() int64 {
+	pe.lastID++
+	return pe.lastID
+}
+
+// match fills the remaining quantity of a order against the order book.
+// NOTE: A taker fills at the book prices while a resting order fills at its limit price.
+func (pe *PaperExecutor) match(po *paperOrder, bids []binance.Bid, asks []binance.Ask, taker bool) {
+	remaining := parseFloat(po.order.Quantity) - po.report.Quantity
+	levels := asks
+	if po.order.Side == binance.SideTypeSell {
+		levels = bids
+	}
+	for _, level := range levels {
+		price, quantity, err := level.Parse()
+		if err != nil || remaining <= 0 {
+			break
+		}
+		if po.report.Price > 0 && ((po.order.Side == binance.SideTypeBuy && price > po.report.Price) || (po.order.Side == binance.SideTypeSell && price < po.report.Price)) {
+			break
+		}
+		if !taker {
+			price = po.report.Price
+		}
+
+		// Fill level.
+		fillQuantity := math.Min(quantity, remaining)
+		if po.order.Side == binance.SideTypeBuy {
+			po.report.add(price, fillQuantity, fillQuantity*pe.feeRate, po.order.BaseAsset, po.order.BaseAsset, po.order.QuoteAsset)
+		} else {
+			po.report.add(price, fillQuantity, price*fillQuantity*pe.feeRate, po.order.QuoteAsset, po.order.BaseAsset, po.order.QuoteAsset)
+		}
+		remaining -= fillQuantity
+	}
+
+	switch {
+	case remaining <= 1e-12:
+		po.report.Status = binance.OrderStatusTypeFilled
+	case po.order.Type == binance.OrderTypeMarket:
+		po.report.Status = binance.OrderStatusTypeExpired // NOTE: Like on Binance, the remainder of a market order expires when the book is exhausted.
+	case po.report.Quantity > 0:
+		po.report.Status = binance.OrderStatusTypePartiallyFilled
+	}
+	po.report.Time = time.Now()
+}
+
+// cancelSiblings cancels the other legs of the OCO order of a order.
+func (pe *PaperExecutor) cancelSiblings(po *paperOrder) {
+	for _, orderID := range pe.orderLists[po.orderListID] {
+		if sibling := pe.orders[orderID]; sibling != po && !isFinal(sibling.report.Status) {
+			sibling.report.Status = binance.OrderStatusTypeCanceled
+		}
+	}
+}
+
+// order returns a simulated order.
+func (pe *PaperExecutor) order(symbol string, orderID int64) (*paperOrder, error) {
+	po, ok := pe.orders[orderID]
+	if !ok || po.order.Symbol != symbol {
+		return nil, fmt.Errorf("unknown %s paper order %d", symbol, orderID)
+	}
+	return po, nil
+}
+
+// CreateOrder simulates a order against the order book.
+func (pe *PaperExecutor) CreateOrder(order Order) (OrderReport, error) {
+	bids, asks, err := pe.OrderBook(order.Symbol)
+	if err != nil {
+		return OrderReport{}, err
+	}
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+
+	po := &paperOrder{
+		order: order,
+		report: OrderReport{
+			OrderID: pe.nextID(),
+			Type:    order.Type,
+			Status:  binance.OrderStatusTypeNew,
+			Price:   parseFloat(order.Price),
+		},
+	}
+	pe.match(po, bids, asks, true)
+	pe.orders[po.report.OrderID] = po
+	return po.report, nil
+}
+
+// GetOrder matches a open order against the current order book and returns its state.
+// NOTE: The stop loss leg of a OCO order is triggered (and the other leg canceled) once the best bid falls to its stop price.
+func (pe *PaperExecutor) GetOrder(symbol string, orderID int64) (OrderReport, error) {
+	pe.mutex.Lock()
+	po, err := pe.order(symbol, orderID)
+	if err != nil {
+		pe.mutex.Unlock()
+		return OrderReport{}, err
+	}
+	report := po.report
+	pe.mutex.Unlock()
+	if isFinal(report.Status) {
+		return report, nil
+	}
+	bids, asks, err := pe.OrderBook(symbol)
+	if err != nil {
+		return report, err
+	}
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	if isFinal(po.report.Status) { // NOTE: The order can be filled or canceled while the order book is retrieved.
+		return po.report, nil
+	}
+
+	// Match order.
+	switch {
+	case po.stopPrice > 0 && !po.triggered:
+		if len(bids) == 0 {
+			break
+		}
+		if bestBid, _, err := bids[0].Parse(); err == nil && bestBid <= po.stopPrice {
+			po.triggered = true
+			pe.cancelSiblings(po)
+			pe.match(po, bids, asks, true)
+		}
+	default:
+		pe.match(po, bids, asks, false)
+	}
+	if po.report.Quantity > 0 {
+		pe.cancelSiblings(po)
+	}
+	return po.report, nil
+}
+
+// FindOrder returns the state of a simulated order with a given client order ID.
+// NOTE: The order is not matched against the order book (see GetOrder).
+func (pe *PaperExecutor) FindOrder(symbol string, clientOrderID string) (OrderReport, bool, error) {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	for _, po := range pe.orders {
+		if po.order.Symbol == symbol && clientOrderID != "" && po.order.ClientOrderID == clientOrderID {
+			return po.report, true, nil
+		}
+	}
+	return OrderReport{}, false, nil
+}
+
+// CancelOrder cancels the remainder of a open order.
+func (pe *PaperExecutor) CancelOrder(symbol string, orderID int64) error {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	po, err := pe.order(symbol, orderID)
+	if err != nil {
+		return err
+	}
+	if isFinal(po.report.Status) {
+		return fmt.Errorf("%s paper order %d is %s", symbol, orderID, po.report.Status)
+	}
+	po.report.Status = binance.OrderStatusTypeCanceled
+	return nil
+}
+
+// OrderFill returns the simulated fills of a order.
+func (pe *PaperExecutor) OrderFill(symbol string, baseAsset string, quoteAsset string, orderID int64) (Fill, error) {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	po, err := pe.order(symbol, orderID)
+	if err != nil {
+		return Fill{}, err
+	}
+	return po.report.Fill, nil
+}
+
+// CreateOCO simulates a OCO sell order with a take profit limit maker leg and a stop loss stop-limit leg.
+func (pe *PaperExecutor) CreateOCO(order OCOOrder) (report OCOReport, err error) {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	report.OrderListID = pe.nextID()
+	legs := []*paperOrder{
+		{
+			order:  Order{Symbol: order.Symbol, BaseAsset: order.BaseAsset, QuoteAsset: order.QuoteAsset, Side: binance.SideTypeSell, Type: binance.OrderTypeLimitMaker, Quantity: order.Quantity, Price: order.Price},
+			report: OrderReport{Type: binance.OrderTypeLimitMaker, Price: parseFloat(order.Price)},
+		},
+		{
+			order:     Order{Symbol: order.Symbol, BaseAsset: order.BaseAsset, QuoteAsset: order.QuoteAsset, Side: binance.SideTypeSell, Type: binance.OrderTypeStopLossLimit, Quantity: order.Quantity, Price: order.StopLimitPrice},
+			report:    OrderReport{Type: binance.OrderTypeStopLossLimit, Price: parseFloat(order.StopLimitPrice)},
+			stopPrice: parseFloat(order.StopPrice),
+		},
+	}
+	for _, leg := range legs {
+		leg.orderListID = report.OrderListID
+		leg.report.OrderID = pe.nextID()
+		leg.report.Status = binance.OrderStatusTypeNew
+		leg.report.Time = time.Now()
+		pe.orders[leg.report.OrderID] = leg
+		report.OrderIDs = append(report.OrderIDs, leg.report.OrderID)
+	}
+	pe.orderLists[report.OrderListID] = report.OrderIDs
+	return report, nil
+}
+
+// CancelOCO cancels the open legs of a OCO order.
+func (pe *PaperExecutor) CancelOCO(symbol string, orderListID int64) error {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	orderIDs, ok := pe.orderLists[orderListID]
+	if !ok {
+		return fmt.Errorf("unknown %s paper order list %d", symbol, orderListID)
+	}
+	for _, orderID := range orderIDs {
+		if po := pe.orders[orderID]; !isFinal(po.report.Status) {
+			po.report.Status = binance.OrderStatusTypeCanceled
+		}
+	}
+	return nil
+}
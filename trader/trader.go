@@ -0,0 +1,370 @@
+// Description: Package trader contains a class that places a buy order when a new Binance listing is found and manages the exit of the position. The orders are placed on Binance or simulated against the order book (paper trading). It implements the messaging.Notifier interface so that it receives the listing events of the Binance listings checker.
+package trader
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+	"github.com/rickstaa/crypto-listings-sniper/messaging"
+	"github.com/rickstaa/crypto-listings-sniper/utils"
+)
+
+// Entry order types.
+const (
+	ORDER_MARKET = "market" // Market order sized with the order book so that it does not fill above the slippage cap.
+	ORDER_LIMIT  = "limit"  // GTC limit order at the slippage cap.
+)
+
+// Trader settings.
+const (
+	TRADING_POLL_INTERVAL = 1 * time.Second // Interval at which the symbol status and order book are checked until the symbol can be traded.
+	DEPTH_LIMIT           = 100
+	ORDER_POLL_INTERVAL   = 2 * time.Second  // Interval at which the entry and exit orders are checked until they are filled.
+	ORDER_LOOKUP_ATTEMPTS = 5                // Number of times a order whose placement failed is looked up before its state is considered unknown.
+	EXIT_MAX_BACKOFF      = 30 * time.Second // Maximum wait before the trade stream is reconnected or a failed exit order is retried.
+)
+
+// Config represents the trader configuration.
+type Config struct {
+	QuoteAssets map[string]float64 // Max notional of a entry order per quote asset (e.g. USDT: 100).
+	OrderType   string
+	Slippage    float64       // Max price above the best ask as a fraction of the best ask (e.g. 0.05).
+	MaxWait     time.Duration // Max time to wait until a new symbol can be traded and until a limit entry order is filled.
+
+	ExitMode     string        // EXIT_MODE_OCO, EXIT_MODE_TRAILING or empty to keep the position open.
+	TakeProfit   float64       // Take profit above the entry price as a fraction of the entry price (zero to disable).
+	StopLoss     float64       // Stop loss below the entry price as a fraction of the entry price (zero to disable).
+	TrailingStop float64       // Trailing stop below the highest traded price as a fraction of that price (zero to disable).
+	MaxHoldTime  time.Duration // Max time the position is held before it is sold at market (zero to hold until a exit target is hit).
+
+	MaxBurst int // Max number of listings found in the same check that are entered (zero to disable). Larger bursts are likely a lost baseline.
+
+	PaperTrading bool    // Simulate the orders against the live order book instead of placing them.
+	PaperFeeRate float64 // Simulated commission rate (e.g. PAPER_FEE_RATE).
+}
+
+// Fill represents the aggregated fills of a order.
+type Fill struct {
+	Quantity  float64
+	Notional  float64
+	QuoteFees float64 // Commission paid in the quote asset.
+	BaseFees  float64 // Commission paid in the base asset (i.e. deducted from the bought quantity).
+}
+
+// add adds a fill of a order.
+// NOTE: Commission paid in other assets (e.g. BNB) is not included.
+func (f *Fill) add(price float64, quantity float64, commission float64, commissionAsset string, baseAsset string, quoteAsset string) {
+	f.Quantity += quantity
+	f.Notional += price * quantity
+	switch commissionAsset {
+	case quoteAsset:
+		f.QuoteFees += commission
+	case baseAsset:
+		f.BaseFees += commission
+	}
+}
+
+// merge adds the fills of another order.
+func (f *Fill) merge(other Fill) {
+	f.Quantity += other.Quantity
+	f.Notional += other.Notional
+	f.QuoteFees += other.QuoteFees
+	f.BaseFees += other.BaseFees
+}
+
+// AveragePrice returns the average fill price.
+func (f Fill) AveragePrice() float64 {
+	if f.Quantity == 0 {
+		return 0
+	}
+	return f.Notional / f.Quantity
+}
+
+// Fees returns the commission in the quote asset.
+func (f Fill) Fees() float64 {
+	return f.QuoteFees + f.BaseFees*f.AveragePrice()
+}
+
+// Entry represents a entry order that was placed on a new listing.
+type Entry struct {
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+	OrderID    int64
+	OrderType  string
+	Status     string
+	Price      float64 // Average fill price or the limit price if nothing was filled.
+	Fill
+	Time    time.Time
+	filters symbolFilters
+}
+
+// Trader is a class that places a buy order on new Binance listings that are quoted in one of the configured quote assets.
+// NOTE: Removed assets, futures contracts and product assets are ignored.
+type Trader struct {
+	BinanceClient     *binance.Client
+	Executor          Executor
+	Notifier          messaging.Notifier
+	config            Config
+	entered           map[string]bool
+	enteredMutex      sync.Mutex
+	pollInterval      time.Duration
+	orderPollInterval time.Duration
+	tradeStream       tradeStream
+}
+
+// NewTrader creates a new Trader that posts the closed trades using a given notifier.
+// NOTE: The orders are simulated against the live order book when paper trading is enabled.
+func NewTrader(binanceClient *binance.Client, config Config, notifier messaging.Notifier) *Trader {
+	executor := Executor(NewBinanceExecutor(binanceClient))
+	if config.PaperTrading {
+		executor = NewPaperExecutor(LiveOrderBook(binanceClient), config.PaperFeeRate)
+	}
+	return &Trader{
+		BinanceClient:     binanceClient,
+		Executor:          executor,
+		Notifier:          notifier,
+		config:            config,
+		entered:           make(map[string]bool),
+		pollInterval:      TRADING_POLL_INTERVAL,
+		orderPollInterval: ORDER_POLL_INTERVAL,
+		tradeStream:       binanceTradeStream,
+	}
+}
+
+// Name returns the name of the trader.
+func (t *Trader) Name() string {
+	return "Trader"
+}
+
+// NotifyAsset places a entry order when a new listing is quoted in one of the configured quote assets and manages its exit.
+// NOTE: The orders are placed in the background so that the listing messages are not delayed while waiting until the symbol can be traded.
+func (t *Trader) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	if removed || assetInfo.IsFuturesContract() || assetInfo.IsProduct() {
+		return nil
+	}
+	if _, ok := t.config.QuoteAssets[assetInfo.QuoteAsset]; !ok {
+		return nil
+	}
+	if t.config.MaxBurst > 0 && assetInfo.BatchSize > t.config.MaxBurst {
+		log.Printf("WARNING: Not entering %s since %d listings were found at once (max %d).", assetInfo.Symbol, assetInfo.BatchSize, t.config.MaxBurst)
+		return nil
+	}
+
+	go func() {
+		entry, err := t.Enter(assetInfo.Symbol)
+		if err != nil {
+			log.Printf("WARNING: Error placing %s entry order: %v", assetInfo.Symbol, err)
+			return
+		}
+		log.Printf("Placed %s %s%s entry order %d: %s %s at %s (%s).", entry.Symbol, paperPrefix(t.Executor), entry.OrderType, entry.OrderID, utils.FormatAmount(entry.Quantity), entry.BaseAsset, utils.FormatAmount(entry.Price), entry.Status)
+		if t.config.ExitMode == "" {
+			return
+		}
+
+		// Manage exit.
+		trade, err := t.Exit(entry)
+		if err != nil {
+			log.Printf("WARNING: Error exiting %s position: %v", entry.Symbol, err)
+			return
+		}
+		log.Printf("Closed %s %sposition (%s): %s %s PnL.", trade.Symbol, paperPrefix(t.Executor), trade.ExitReason, utils.FormatAmount(trade.PnL), trade.QuoteAsset)
+		if t.Notifier != nil {
+			t.Notifier.NotifyTrade(trade)
+		}
+	}()
+	return nil
+}
+
+// NotifyAnnouncement ignores the announcements.
+func (t *Trader) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return nil
+}
+
+// NotifyStatus ignores the symbol status transitions.
+func (t *Trader) NotifyStatus(transition exchanges.StatusTransition) error {
+	return nil
+}
+
+// NotifyTrade ignores the closed trades.
+func (t *Trader) NotifyTrade(trade exchanges.Trade) error {
+	return nil
+}
+
+// paperPrefix returns the log prefix of the simulated orders.
+func paperPrefix(executor Executor) string {
+	if executor.Simulated() {
+		return "paper "
+	}
+	return ""
+}
+
+// waitForTrading waits until a symbol is trading and its order book contains asks.
+func (t *Trader) waitForTrading(symbol string) (binance.Symbol, []binance.Ask, error) {
+	deadline := time.Now().Add(t.config.MaxWait)
+	for {
+		exchangeInfo, err := t.BinanceClient.NewExchangeInfoService().Symbol(symbol).Do(context.Background())
+		if err == nil && len(exchangeInfo.Symbols) != 0 && exchangeInfo.Symbols[0].Status == exchanges.STATUS_TRADING {
+			depth, depthErr := t.BinanceClient.NewDepthService().Symbol(symbol).Limit(DEPTH_LIMIT).Do(context.Background())
+			if depthErr == nil && len(depth.Asks) != 0 {
+				return exchangeInfo.Symbols[0], depth.Asks, nil
+			}
+			err = depthErr
+		}
+
+		if time.Now().After(deadline) {
+			if err == nil {
+				err = fmt.Errorf("symbol not trading after %v", t.config.MaxWait)
+			}
+			return binance.Symbol{}, nil, err
+		}
+		time.Sleep(t.pollInterval)
+	}
+}
+
+// marketQuantity returns the quantity that can be bought with the max notional from the asks up to the cap price and its estimated cost.
+func marketQuantity(asks []binance.Ask, capPrice float64, maxNotional float64) (quantity float64, cost float64) {
+	for _, ask := range asks {
+		price, askQuantity, err := ask.Parse()
+		if err != nil || price > capPrice {
+			break
+		}
+		if cost+price*askQuantity >= maxNotional {
+			return quantity + (maxNotional-cost)/price, maxNotional
+		}
+		quantity += askQuantity
+		cost += price * askQuantity
+	}
+	return quantity, cost
+}
+
+// newClientOrderID returns a unique client order ID.
+func newClientOrderID() string {
+	return "sniper-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// lookupOrder looks up a order whose placement failed with a error that does not tell whether it was placed (e.g. a timeout).
+// NOTE: The order state is unknown when it could not be looked up.
+func (t *Trader) lookupOrder(order Order) (report OrderReport, found bool, unknown bool) {
+	for attempt := 0; attempt < ORDER_LOOKUP_ATTEMPTS; attempt++ {
+		if attempt > 0 {
+			time.Sleep(t.orderPollInterval)
+		}
+		report, found, err := t.Executor.FindOrder(order.Symbol, order.ClientOrderID)
+		if err == nil {
+			return report, found, false
+		}
+		log.Printf("WARNING: Error looking up %s order '%s': %v", order.Symbol, order.ClientOrderID, err)
+	}
+	return report, false, true
+}
+
+// Enter waits until a symbol can be traded and places a buy order of at most the max notional of its quote asset.
+// NOTE: The order quantity and price are rounded down to the 'LOT_SIZE' (or 'MARKET_LOT_SIZE') and 'PRICE_FILTER' filters of the symbol.
+func (t *Trader) Enter(symbol string) (entry Entry, err error) {
+	t.enteredMutex.Lock()
+	if t.entered[symbol] {
+		t.enteredMutex.Unlock()
+		return entry, fmt.Errorf("symbol '%s' was already entered", symbol)
+	}
+	t.entered[symbol] = true
+	t.enteredMutex.Unlock()
+	orderUnknown := false
+	defer func() {
+		// NOTE: The symbol can be entered again when no order was placed (e.g. it was not trading yet or the order was rejected) but not when
+		// the order may have been placed.
+		if err != nil && !orderUnknown {
+			t.enteredMutex.Lock()
+			delete(t.entered, symbol)
+			t.enteredMutex.Unlock()
+		}
+	}()
+
+	// Retrieve symbol filters and order book.
+	symbolInfo, asks, err := t.waitForTrading(symbol)
+	if err != nil {
+		return entry, err
+	}
+	filters, err := newSymbolFilters(symbolInfo)
+	if err != nil {
+		return entry, err
+	}
+	maxNotional, ok := t.config.QuoteAssets[symbolInfo.QuoteAsset]
+	if !ok {
+		return entry, fmt.Errorf("quote asset '%s' not configured", symbolInfo.QuoteAsset)
+	}
+	bestAsk, _, err := asks[0].Parse()
+	if err != nil {
+		return entry, err
+	}
+	capPrice := bestAsk * (1 + t.config.Slippage)
+
+	// Create order.
+	order := Order{Symbol: symbol, BaseAsset: symbolInfo.BaseAsset, QuoteAsset: symbolInfo.QuoteAsset, Side: binance.SideTypeBuy, ClientOrderID: newClientOrderID()}
+	var quantity, notional, minQuantity float64
+	switch t.config.OrderType {
+	case ORDER_MARKET:
+		bookQuantity, bookCost := marketQuantity(asks, capPrice, maxNotional)
+		quantity, order.Quantity = roundDown(clamp(bookQuantity, filters.marketMaxQty), filters.marketStepSize)
+		if bookQuantity > 0 {
+			notional = bookCost * quantity / bookQuantity // NOTE: Estimated with the average price of the order book.
+		}
+		minQuantity = filters.marketMinQty
+		order.Type = binance.OrderTypeMarket
+	case ORDER_LIMIT:
+		var price float64
+		price, order.Price = roundDown(clamp(capPrice, filters.maxPrice), filters.tickSize)
+		quantity, order.Quantity = roundDown(clamp(maxNotional/price, filters.maxQuantity), filters.stepSize)
+		notional = quantity * price
+		minQuantity = filters.minQuantity
+		order.Type = binance.OrderTypeLimit
+	default:
+		return entry, fmt.Errorf("unknown order type '%s'", t.config.OrderType)
+	}
+	if quantity <= 0 || quantity < minQuantity {
+		return entry, fmt.Errorf("order quantity %s below the minimum quantity %s", order.Quantity, utils.FormatAmount(minQuantity))
+	}
+	if notional < filters.minNotional {
+		return entry, fmt.Errorf("order notional %s below the minimum notional %s", utils.FormatAmount(notional), utils.FormatAmount(filters.minNotional))
+	}
+
+	// Place order.
+	report, err := t.Executor.CreateOrder(order)
+	if err != nil && !isRejected(err) {
+		var found bool
+		report, found, orderUnknown = t.lookupOrder(order)
+		if orderUnknown {
+			return entry, fmt.Errorf("entry order '%s' may have been placed: %w", order.ClientOrderID, err)
+		}
+		if !found {
+			return entry, err
+		}
+		log.Printf("WARNING: Found %s entry order %d after its placement failed: %v", symbol, report.OrderID, err)
+		err = nil
+	}
+	if err != nil {
+		return entry, err
+	}
+	entry = Entry{
+		Symbol:     symbol,
+		BaseAsset:  symbolInfo.BaseAsset,
+		QuoteAsset: symbolInfo.QuoteAsset,
+		OrderID:    report.OrderID,
+		OrderType:  t.config.OrderType,
+		Status:     string(report.Status),
+		Price:      report.Price,
+		Fill:       report.Fill,
+		Time:       report.Time,
+		filters:    filters,
+	}
+	if entry.Quantity > 0 {
+		entry.Price = entry.AveragePrice()
+	}
+	return entry, nil
+}
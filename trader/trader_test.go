@@ -0,0 +1,516 @@
+// Description: Tests for the trader package.
+
+package trader
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/rickstaa/crypto-listings-sniper/exchanges"
+)
+
+// SYMBOL_INFO contains the exchange info of a new listing with its trading filters.
+const SYMBOL_INFO = `{"symbol":"FOOUSDT","status":"%s","baseAsset":"FOO","quoteAsset":"USDT","filters":[` +
+	`{"filterType":"PRICE_FILTER","minPrice":"0.01000000","maxPrice":"1000.00000000","tickSize":"0.01000000"},` +
+	`{"filterType":"LOT_SIZE","minQty":"0.10000000","maxQty":"9000.00000000","stepSize":"0.10000000"},` +
+	`{"filterType":"MARKET_LOT_SIZE","minQty":"0.00000000","maxQty":"5000.00000000","stepSize":"0.00000000"},` +
+	`{"filterType":"NOTIONAL","minNotional":"%s","applyMinToMarket":true}]}`
+
+// newBinanceStandIn returns a local HTTP server that stands in for the Binance API. The symbol starts trading after the first exchange info request
+// and the created orders are passed to the order handler.
+func newBinanceStandIn(t *testing.T, minNotional string, orderHandler func(r *http.Request)) *httptest.Server {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/exchangeInfo", func(w http.ResponseWriter, r *http.Request) {
+		status := "BREAK"
+		if requests++; requests > 1 {
+			status = "TRADING"
+		}
+		w.Write([]byte(`{"symbols":[` + fmt.Sprintf(SYMBOL_INFO, status, minNotional) + `]}`))
+	})
+	mux.HandleFunc("/api/v3/depth", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"lastUpdateId":1,"bids":[],"asks":[["1.00","50"],["1.02","100"],["1.10","1000"]]}`))
+	})
+	mux.HandleFunc("/api/v3/order", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected %s, got %s", http.MethodPost, r.Method)
+		}
+		orderHandler(r)
+		w.Write([]byte(`{"symbol":"FOOUSDT","orderId":1,"transactTime":1709280000000,"price":"1.05","executedQty":"10","cummulativeQuoteQty":"10.2","status":"PARTIALLY_FILLED"}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+// newTestTrader returns a trader that sends its requests to a given server.
+func newTestTrader(server *httptest.Server, orderType string) *Trader {
+	client := binance.NewClient("key", "secret")
+	client.BaseURL = server.URL
+	trader := NewTrader(client, Config{QuoteAssets: map[string]float64{"USDT": 100}, OrderType: orderType, Slippage: 0.05, MaxWait: time.Second}, nil)
+	trader.pollInterval = time.Millisecond
+	trader.orderPollInterval = time.Millisecond
+	return trader
+}
+
+// newTestEntry returns a filled entry of 10 FOO at 1 USDT of which 0.01 FOO was paid as commission.
+func newTestEntry() Entry {
+	return Entry{
+		Symbol:     "FOOUSDT",
+		BaseAsset:  "FOO",
+		QuoteAsset: "USDT",
+		OrderID:    1,
+		Status:     string(binance.OrderStatusTypeFilled),
+		Price:      1,
+		Fill:       Fill{Quantity: 10, Notional: 10, BaseFees: 0.01},
+		filters:    symbolFilters{stepSize: "0.10000000", minQuantity: 0.1, marketStepSize: "0.10000000", tickSize: "0.01000000"},
+	}
+}
+
+// tradeNotifier is a notifier that records the posted trades.
+type tradeNotifier struct {
+	trades []exchanges.Trade
+}
+
+func (tn *tradeNotifier) Name() string {
+	return "Trades"
+}
+
+func (tn *tradeNotifier) NotifyAsset(removed bool, assetInfo exchanges.SymbolInfo) error {
+	return nil
+}
+
+func (tn *tradeNotifier) NotifyAnnouncement(announcement exchanges.Announcement) error {
+	return nil
+}
+
+func (tn *tradeNotifier) NotifyStatus(transition exchanges.StatusTransition) error {
+	return nil
+}
+
+func (tn *tradeNotifier) NotifyTrade(trade exchanges.Trade) error {
+	tn.trades = append(tn.trades, trade)
+	return nil
+}
+
+// equal returns whether two amounts are equal up to float errors.
+func equal(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestRoundDown tests the roundDown function.
+func TestRoundDown(t *testing.T) {
+	tests := []struct {
+		value    float64
+		step     string
+		expected string
+	}{
+		{95.238, "0.10000000", "95.2"},
+		{0.3, "0.10000000", "0.3"},
+		{1.05, "0.01000000", "1.05"},
+		{1234.5, "1.00000000", "1234"},
+		{1.5, "0.00000000", "1.5"},
+	}
+	for _, test := range tests {
+		if _, rounded := roundDown(test.value, test.step); rounded != test.expected {
+			t.Errorf("Expected %s, got %s", test.expected, rounded)
+		}
+	}
+}
+
+// TestEnterLimit tests that a limit order is placed at the slippage cap and rounded to the symbol filters once the symbol is trading.
+func TestEnterLimit(t *testing.T) {
+	orders := 0
+	server := newBinanceStandIn(t, "5.00000000", func(r *http.Request) {
+		orders++
+		if r.FormValue("type") != "LIMIT" || r.FormValue("timeInForce") != "GTC" || r.FormValue("side") != "BUY" {
+			t.Errorf("Expected %s, got %s", "LIMIT GTC BUY", r.FormValue("type")+" "+r.FormValue("timeInForce")+" "+r.FormValue("side"))
+		}
+		if r.FormValue("price") != "1.05" || r.FormValue("quantity") != "95.2" {
+			t.Errorf("Expected %s, got %s", "95.2 at 1.05", r.FormValue("quantity")+" at "+r.FormValue("price"))
+		}
+	})
+	defer server.Close()
+	trader := newTestTrader(server, ORDER_LIMIT)
+
+	entry, err := trader.Enter("FOOUSDT")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if orders != 1 || entry.OrderID != 1 || entry.Quantity != 10 || entry.Price != 1.02 {
+		t.Errorf("Expected %s, got %v", "order 1 with 10 FOO at 1.02", entry)
+	}
+	if _, err := trader.Enter("FOOUSDT"); err == nil || orders != 1 {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestEnterMarket tests that a market order is sized with the asks up to the slippage cap.
+func TestEnterMarket(t *testing.T) {
+	server := newBinanceStandIn(t, "5.00000000", func(r *http.Request) {
+		if r.FormValue("type") != "MARKET" || r.FormValue("price") != "" {
+			t.Errorf("Expected %s, got %s", "MARKET", r.FormValue("type"))
+		}
+		if r.FormValue("quantity") != "99.0" {
+			t.Errorf("Expected %s, got %s", "99.0", r.FormValue("quantity"))
+		}
+	})
+	defer server.Close()
+
+	if _, err := newTestTrader(server, ORDER_MARKET).Enter("FOOUSDT"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestEnterMinNotional tests that no order is placed when the max notional is below the minimum notional of the symbol.
+func TestEnterMinNotional(t *testing.T) {
+	server := newBinanceStandIn(t, "200.00000000", func(r *http.Request) {
+		t.Errorf("Expected no order, got %s", r.Form.Encode())
+	})
+	defer server.Close()
+
+	trader := newTestTrader(server, ORDER_LIMIT)
+	if _, err := trader.Enter("FOOUSDT"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if trader.entered["FOOUSDT"] {
+		t.Errorf("Expected %s, got %s", "symbol not entered", "entered")
+	}
+}
+
+// TestEnterOrderFailure tests that a entry order whose placement failed is looked up by its client order ID and that the symbol can only be
+// entered again when the order was certainly not placed.
+func TestEnterOrderFailure(t *testing.T) {
+	tests := []struct {
+		createError string
+		lookup      string // 'found', 'missing' or 'error'.
+		entered     bool
+		err         bool
+	}{
+		{`{"code":-1007,"msg":"Timeout waiting for response from backend server."}`, "found", true, false},
+		{`{"code":-1007,"msg":"Timeout waiting for response from backend server."}`, "missing", false, true},
+		{`{"code":-1007,"msg":"Timeout waiting for response from backend server."}`, "error", true, true},
+		{`{"code":-2010,"msg":"Account has insufficient balance for requested action."}`, "found", false, true},
+	}
+	for _, test := range tests {
+		var clientOrderID string
+		server := newBinanceStandIn(t, "5.00000000", nil)
+		mux := http.NewServeMux()
+		mux.Handle("/", server.Config.Handler)
+		mux.HandleFunc("/api/v3/order", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				clientOrderID = r.FormValue("newClientOrderId")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(test.createError))
+				return
+			}
+			switch {
+			case test.lookup == "error":
+				w.WriteHeader(http.StatusInternalServerError)
+			case test.lookup == "missing" || r.FormValue("origClientOrderId") != clientOrderID:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":-2013,"msg":"Order does not exist."}`))
+			default:
+				w.Write([]byte(`{"symbol":"FOOUSDT","orderId":1,"price":"1.05","executedQty":"10","cummulativeQuoteQty":"10.2","status":"PARTIALLY_FILLED"}`))
+			}
+		})
+		server.Config.Handler = mux
+		trader := newTestTrader(server, ORDER_LIMIT)
+
+		entry, err := trader.Enter("FOOUSDT")
+		if (err != nil) != test.err || trader.entered["FOOUSDT"] != test.entered {
+			t.Errorf("Expected error %v and entered %v for %s (%s), got %v and %v", test.err, test.entered, test.createError, test.lookup, err, trader.entered["FOOUSDT"])
+		}
+		if !test.err && entry.OrderID != 1 {
+			t.Errorf("Expected %d, got %d", 1, entry.OrderID)
+		}
+		server.Close()
+	}
+}
+
+// TestNotifyAssetBurst tests that no entry order is placed when more listings than the max burst were found at once.
+func TestNotifyAssetBurst(t *testing.T) {
+	server := newBinanceStandIn(t, "5.00000000", func(r *http.Request) {
+		t.Errorf("Expected no order, got %s", r.Form.Encode())
+	})
+	defer server.Close()
+	trader := newTestTrader(server, ORDER_LIMIT)
+	trader.config.MaxBurst = 3
+
+	trader.NotifyAsset(false, exchanges.SymbolInfo{Symbol: "FOOUSDT", QuoteAsset: "USDT", BatchSize: 4})
+	time.Sleep(10 * time.Millisecond)
+	trader.enteredMutex.Lock()
+	defer trader.enteredMutex.Unlock()
+	if trader.entered["FOOUSDT"] {
+		t.Errorf("Expected FOOUSDT not to be entered")
+	}
+}
+
+// TestMarketFilters tests that the market orders use the 'MARKET_LOT_SIZE' filter when it has a step size.
+func TestMarketFilters(t *testing.T) {
+	symbol := binance.Symbol{Symbol: "FOOUSDT", Filters: []map[string]interface{}{
+		{"filterType": "LOT_SIZE", "minQty": "0.10000000", "maxQty": "9000.00000000", "stepSize": "0.10000000"},
+		{"filterType": "MARKET_LOT_SIZE", "minQty": "1.00000000", "maxQty": "5000.00000000", "stepSize": "1.00000000"},
+	}}
+	filters, err := newSymbolFilters(symbol)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filters.marketStepSize != "1.00000000" || filters.marketMinQty != 1 || filters.marketMaxQty != 5000 {
+		t.Errorf("Expected %s, got %v", "MARKET_LOT_SIZE 1-5000 step 1", filters)
+	}
+}
+
+// TestExitOCO tests that a OCO order is placed at the take profit and stop loss and that the trade is built from the fills of the filled leg.
+func TestExitOCO(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/order/oco", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("side") != "SELL" || r.FormValue("quantity") != "9.9" {
+			t.Errorf("Expected %s, got %s", "SELL 9.9", r.FormValue("side")+" "+r.FormValue("quantity"))
+		}
+		if r.FormValue("price") != "1.50" || r.FormValue("stopPrice") != "0.90" || r.FormValue("stopLimitPrice") != "0.85" {
+			t.Errorf("Expected %s, got %s", "1.50/0.90/0.85", r.FormValue("price")+"/"+r.FormValue("stopPrice")+"/"+r.FormValue("stopLimitPrice"))
+		}
+		w.Write([]byte(`{"orderListId":7,"symbol":"FOOUSDT","orders":[{"symbol":"FOOUSDT","orderId":2},{"symbol":"FOOUSDT","orderId":3}]}`))
+	})
+	mux.HandleFunc("/api/v3/order", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("orderId") == "3" {
+			w.Write([]byte(`{"symbol":"FOOUSDT","orderId":3,"status":"FILLED","type":"LIMIT_MAKER"}`))
+			return
+		}
+		w.Write([]byte(`{"symbol":"FOOUSDT","orderId":2,"status":"NEW","type":"STOP_LOSS_LIMIT"}`))
+	})
+	mux.HandleFunc("/api/v3/myTrades", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("orderId") != "3" {
+			t.Errorf("Expected %s, got %s", "3", r.FormValue("orderId"))
+		}
+		w.Write([]byte(`[{"symbol":"FOOUSDT","orderId":3,"price":"1.50","qty":"9.9","commission":"0.01485","commissionAsset":"USDT"}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	trader := newTestTrader(server, ORDER_LIMIT)
+	trader.config.ExitMode, trader.config.TakeProfit, trader.config.StopLoss = EXIT_MODE_OCO, 0.5, 0.1
+
+	trade, err := trader.Exit(newTestEntry())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if trade.ExitReason != exchanges.EXIT_TAKE_PROFIT {
+		t.Errorf("Expected %s, got %s", exchanges.EXIT_TAKE_PROFIT, trade.ExitReason)
+	}
+	if !equal(trade.Quantity, 9.9) || !equal(trade.ExitPrice, 1.5) || !equal(trade.Fees, 0.02485) || !equal(trade.PnL, 4.83515) {
+		t.Errorf("Expected %s, got %v", "9.9 sold at 1.5 with 0.02485 fees and 4.83515 PnL", trade)
+	}
+}
+
+// TestExitTrailing tests that the position is sold at market once the trade stream falls through the trailing stop below the highest price.
+func TestExitTrailing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/order" || r.FormValue("side") != "SELL" || r.FormValue("type") != "MARKET" || r.FormValue("quantity") != "9.9" {
+			t.Errorf("Expected %s, got %s", "MARKET SELL 9.9", r.URL.Path+" "+r.FormValue("type")+" "+r.FormValue("side")+" "+r.FormValue("quantity"))
+		}
+		w.Write([]byte(`{"symbol":"FOOUSDT","orderId":4,"status":"FILLED","fills":[{"price":"1.16","qty":"9.9","commission":"0.011484","commissionAsset":"USDT"}]}`))
+	}))
+	defer server.Close()
+	trader := newTestTrader(server, ORDER_LIMIT)
+	trader.config.ExitMode, trader.config.TakeProfit, trader.config.TrailingStop = EXIT_MODE_TRAILING, 1, 0.1
+	trader.tradeStream = func(symbol string, handler func(price float64), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+		doneC, stopC = make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(doneC)
+			for _, price := range []float64{1.1, 1.3, 1.2, 1.16} {
+				handler(price)
+			}
+			<-stopC
+		}()
+		return doneC, stopC, nil
+	}
+
+	trade, err := trader.Exit(newTestEntry())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if trade.ExitReason != exchanges.EXIT_TRAILING_STOP || !equal(trade.ExitPrice, 1.16) {
+		t.Errorf("Expected %s, got %s at %v", exchanges.EXIT_TRAILING_STOP+" at 1.16", trade.ExitReason, trade.ExitPrice)
+	}
+}
+
+// TestExitTrailingFailures tests that a closed trade stream is reconnected with backoff and that a failed market sell is alerted and retried.
+func TestExitTrailingFailures(t *testing.T) {
+	sells := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sells++; sells == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":-1000,"msg":"An unknown error occurred while processing the request."}`))
+			return
+		}
+		w.Write([]byte(`{"symbol":"FOOUSDT","orderId":4,"status":"FILLED","fills":[{"price":"1.16","qty":"9.9","commission":"0.011484","commissionAsset":"USDT"}]}`))
+	}))
+	defer server.Close()
+	trader := newTestTrader(server, ORDER_LIMIT)
+	notifier := &tradeNotifier{}
+	trader.Notifier = notifier
+	trader.orderPollInterval = 10 * time.Millisecond
+	trader.config.ExitMode, trader.config.TakeProfit, trader.config.TrailingStop = EXIT_MODE_TRAILING, 1, 0.1
+	connects := 0
+	trader.tradeStream = func(symbol string, handler func(price float64), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+		doneC, stopC = make(chan struct{}), make(chan struct{})
+		if connects++; connects <= 3 {
+			close(doneC)
+			return doneC, stopC, nil
+		}
+		go func() {
+			defer close(doneC)
+			for _, price := range []float64{1.3, 1.16} {
+				handler(price)
+			}
+			<-stopC
+		}()
+		return doneC, stopC, nil
+	}
+
+	tStart := time.Now()
+	trade, err := trader.Exit(newTestEntry())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if connects != 4 || time.Since(tStart) < 30*time.Millisecond {
+		t.Errorf("Expected %d connects after at least %v, got %d after %v", 4, 30*time.Millisecond, connects, time.Since(tStart))
+	}
+	if sells != 2 || trade.ExitReason != exchanges.EXIT_TRAILING_STOP || !equal(trade.Quantity, 9.9) {
+		t.Errorf("Expected %s, got %d sells and %s of %v", "2 sells and trailing_stop of 9.9", sells, trade.ExitReason, trade.Quantity)
+	}
+	if len(notifier.trades) != 1 || notifier.trades[0].ExitReason != exchanges.EXIT_MANUAL_INTERVENTION || !equal(notifier.trades[0].Quantity, 9.9) {
+		t.Errorf("Expected a %s alert for 9.9, got %v", exchanges.EXIT_MANUAL_INTERVENTION, notifier.trades)
+	}
+}
+
+// TestPaperMarketOrder tests that a paper market order walks the order book, pays its commission in the received asset and expires when the
+// book is exhausted.
+func TestPaperMarketOrder(t *testing.T) {
+	book := ReplayOrderBook([]binance.DepthResponse{{Asks: []binance.Ask{{Price: "1.00", Quantity: "50"}, {Price: "1.02", Quantity: "100"}}}})
+	executor := NewPaperExecutor(book, PAPER_FEE_RATE)
+
+	report, err := executor.CreateOrder(Order{Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", Side: binance.SideTypeBuy, Type: binance.OrderTypeMarket, Quantity: "120"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Status != binance.OrderStatusTypeFilled || !equal(report.Notional, 121.4) || !equal(report.BaseFees, 0.12) {
+		t.Errorf("Expected %s, got %v", "120 FOO filled for 121.4 USDT with 0.12 FOO fees", report)
+	}
+	report, _ = executor.CreateOrder(Order{Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", Side: binance.SideTypeSell, Type: binance.OrderTypeMarket, Quantity: "10"})
+	if report.Status != binance.OrderStatusTypeExpired || report.Quantity != 0 {
+		t.Errorf("Expected %s, got %s", binance.OrderStatusTypeExpired, report.Status)
+	}
+}
+
+// TestPaperOrderBookUnlocked tests that the paper orders are not blocked while the order book of another order is retrieved.
+func TestPaperOrderBookUnlocked(t *testing.T) {
+	fetching, release := make(chan struct{}), make(chan struct{})
+	executor := NewPaperExecutor(ReplayOrderBook([]binance.DepthResponse{{Asks: []binance.Ask{{Price: "1.00", Quantity: "50"}}}}), PAPER_FEE_RATE)
+	report, err := executor.CreateOrder(Order{Symbol: "FOOUSDT", BaseAsset: "FOO", QuoteAsset: "USDT", Side: binance.SideTypeBuy, Type: binance.OrderTypeLimit, Quantity: "10", Price: "0.90"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	executor.OrderBook = func(symbol string) ([]binance.Bid, []binance.Ask, error) {
+		close(fetching)
+		<-release
+		return nil, nil, nil
+	}
+	go executor.GetOrder("FOOUSDT", report.OrderID)
+	defer close(release)
+	<-fetching
+
+	canceled := make(chan error)
+	go func() { canceled <- executor.CancelOrder("FOOUSDT", report.OrderID) }()
+	select {
+	case err := <-canceled:
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected the order to be canceled while the order book is retrieved")
+	}
+}
+
+// TestPaperExitOCO tests that a paper OCO exit is triggered by a replayed order book and reported as a simulated trade.
+func TestPaperExitOCO(t *testing.T) {
+	trader := NewTrader(binance.NewClient("", ""), Config{Slippage: 0.05, ExitMode: EXIT_MODE_OCO, TakeProfit: 0.5, StopLoss: 0.1, PaperTrading: true}, nil)
+	trader.orderPollInterval = time.Millisecond
+	executor := NewPaperExecutor(ReplayOrderBook([]binance.DepthResponse{
+		{Bids: []binance.Bid{{Price: "0.95", Quantity: "100"}}},
+		{Bids: []binance.Bid{{Price: "0.89", Quantity: "5"}, {Price: "0.86", Quantity: "10"}, {Price: "0.84", Quantity: "100"}}},
+	}), PAPER_FEE_RATE)
+	trader.Executor = executor
+
+	trade, err := trader.Exit(newTestEntry())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !trade.Simulated || trade.ExitReason != exchanges.EXIT_STOP_LOSS {
+		t.Errorf("Expected %s, got %s (simulated: %v)", exchanges.EXIT_STOP_LOSS, trade.ExitReason, trade.Simulated)
+	}
+	if !equal(trade.Quantity, 9.9) || !equal(trade.ExitPrice, (5*0.89+4.9*0.86)/9.9) {
+		t.Errorf("Expected %s, got %v at %v", "9.9 sold at the 0.89 and 0.86 bids", trade.Quantity, trade.ExitPrice)
+	}
+	if report, _ := executor.GetOrder("FOOUSDT", 2); report.Status != binance.OrderStatusTypeCanceled {
+		t.Errorf("Expected %s, got %s", binance.OrderStatusTypeCanceled, report.Status)
+	}
+}
+
+// TestPaperExitOCOPartiallyFilled tests that the remainder of a partially filled OCO exit is sold at market since the stop loss leg is canceled.
+func TestPaperExitOCOPartiallyFilled(t *testing.T) {
+	trader := NewTrader(binance.NewClient("", ""), Config{Slippage: 0.05, ExitMode: EXIT_MODE_OCO, TakeProfit: 0.5, StopLoss: 0.1, PaperTrading: true}, nil)
+	trader.orderPollInterval = time.Millisecond
+	executor := NewPaperExecutor(ReplayOrderBook([]binance.DepthResponse{
+		{Bids: []binance.Bid{{Price: "1.60", Quantity: "4"}}},
+		{Bids: []binance.Bid{{Price: "1.40", Quantity: "100"}}},
+	}), PAPER_FEE_RATE)
+	trader.Executor = executor
+
+	trade, err := trader.Exit(newTestEntry())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if trade.ExitReason != exchanges.EXIT_TAKE_PROFIT {
+		t.Errorf("Expected %s, got %s", exchanges.EXIT_TAKE_PROFIT, trade.ExitReason)
+	}
+	if !equal(trade.Quantity, 9.9) || !equal(trade.ExitPrice, (4*1.5+5.9*1.4)/9.9) {
+		t.Errorf("Expected %s, got %v at %v", "4 sold at 1.5 and 5.9 at 1.4", trade.Quantity, trade.ExitPrice)
+	}
+	if report, _ := executor.GetOrder("FOOUSDT", 2); report.Status != binance.OrderStatusTypeCanceled {
+		t.Errorf("Expected %s, got %s", binance.OrderStatusTypeCanceled, report.Status)
+	}
+}
+
+// TestPaperExitTrailingMarketLotSize tests that the trailing exit quantity is rounded to the 'MARKET_LOT_SIZE' step size.
+func TestPaperExitTrailingMarketLotSize(t *testing.T) {
+	trader := NewTrader(binance.NewClient("", ""), Config{ExitMode: EXIT_MODE_TRAILING, TakeProfit: 1, TrailingStop: 0.1, PaperTrading: true}, nil)
+	trader.Executor = NewPaperExecutor(ReplayOrderBook([]binance.DepthResponse{
+		{Bids: []binance.Bid{{Price: "1.16", Quantity: "100"}}},
+	}), PAPER_FEE_RATE)
+	trader.tradeStream = func(symbol string, handler func(price float64), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+		doneC, stopC = make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(doneC)
+			for _, price := range []float64{1.3, 1.16} {
+				handler(price)
+			}
+			<-stopC
+		}()
+		return doneC, stopC, nil
+	}
+	entry := newTestEntry()
+	entry.filters.marketStepSize, entry.filters.marketMinQty = "1.00000000", 1
+
+	trade, err := trader.Exit(entry)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !equal(trade.Quantity, 9) {
+		t.Errorf("Expected %v, got %v", 9, trade.Quantity)
+	}
+}
@@ -2,22 +2,16 @@
 package utils
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
-	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-var (
-	ASSETS_FILE_PATH        = "data/assets_list.json"
-	ANNOUNCEMENTS_FILE_PATH = "data/announcements_list.json"
-)
-
 // deleteEmpty deletes empty strings from a slice of strings.
 func deleteEmpty(s []string) []string {
 	var r []string
@@ -30,6 +24,100 @@ func deleteEmpty(s []string) []string {
 	return r
 }
 
+// getEnvOrDefault retrieves a environment variable and returns a default value if it is not set.
+func getEnvOrDefault(key string, defaultValue string) string {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// parseRoutes parses a routes string (e.g. 'listing:123|456,delisting:789') into a map of kinds to IDs.
+func parseRoutes(routes string) (map[string][]string, error) {
+	parsedRoutes := make(map[string][]string)
+	for _, route := range deleteEmpty(strings.Split(routes, ",")) {
+		kind, ids, ok := strings.Cut(route, ":")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid route '%s'", route)
+		}
+		for _, id := range strings.Split(ids, "|") {
+			if id = strings.TrimSpace(id); id != "" {
+				parsedRoutes[kind] = append(parsedRoutes[kind], id)
+			}
+		}
+		if len(parsedRoutes[kind]) == 0 {
+			return nil, fmt.Errorf("route '%s' contains no IDs", route)
+		}
+	}
+
+	return parsedRoutes, nil
+}
+
+// parseTelegramRoutes parses a Telegram routes string (e.g. 'listing:-100123|-100456') into a map of kinds to chat IDs.
+func parseTelegramRoutes(routes string) (map[string][]int64, error) {
+	parsedRoutes, err := parseRoutes(routes)
+	if err != nil {
+		return nil, err
+	}
+
+	telegramRoutes := make(map[string][]int64, len(parsedRoutes))
+	for kind, ids := range parsedRoutes {
+		for _, id := range ids {
+			chatID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid chat ID '%s' in route '%s'", id, kind)
+			}
+			telegramRoutes[kind] = append(telegramRoutes[kind], chatID)
+		}
+	}
+	return telegramRoutes, nil
+}
+
+// parseIntList parses a comma separated list of integers (e.g. '48,161').
+func parseIntList(list string) (ints []int64, err error) {
+	for _, item := range deleteEmpty(strings.Split(list, ",")) {
+		i, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer '%s'", item)
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
+
+// parseNotionals parses a comma separated list of assets and notionals (e.g. 'USDT:100,FDUSD:50') into a map of assets to notionals.
+func parseNotionals(list string) (map[string]float64, error) {
+	notionals := make(map[string]float64)
+	for _, item := range deleteEmpty(strings.Split(list, ",")) {
+		asset, notional, ok := strings.Cut(item, ":")
+		asset = strings.ToUpper(strings.TrimSpace(asset))
+		value, err := strconv.ParseFloat(strings.TrimSpace(notional), 64)
+		if !ok || asset == "" || err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid asset notional '%s'", item)
+		}
+		notionals[asset] = value
+	}
+	return notionals, nil
+}
+
+// parseDigestInterval parses a digest mode (i.e. 'immediate', 'hourly' or 'daily') into a digest interval.
+// NOTE: A interval of zero means that the events are sent immediately.
+func parseDigestInterval(mode string) (time.Duration, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "immediate":
+		return 0, nil
+	case "hourly":
+		return time.Hour, nil
+	case "daily":
+		return 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("unknown digest mode '%s'", mode)
+	}
+}
+
 // Contains checks if a string is in a slice of strings.
 func contains(s []string, str string) bool {
 	for _, v := range s {
@@ -43,17 +131,74 @@ func contains(s []string, str string) bool {
 
 // EnvVars represents the programs environment variables.
 type EnvVars struct {
-	BinanceKey               string
-	BinanceSecret            string
-	TelegramBotKey           string
-	TelegramChatID           int64
-	EnableTelegramMessage    bool
-	DiscordBotKey            string
-	DiscordChannelIDs        []string
-	DiscordAppID             string
-	EnableDiscordMessages    bool
-	BinanceListingsRate      float64
-	BinanceAnnouncementsRate float64
+	BinanceKey                 string
+	StateStore                 string
+	SQLiteDBPath               string
+	BinanceSecret              string
+	TelegramBotKey             string
+	TelegramChatID             int64
+	TelegramRoutes             map[string][]int64
+	EnableTelegramMessage      bool
+	DiscordBotKey              string
+	DiscordChannelIDs          []string
+	DiscordRoutes              map[string][]string
+	DiscordAppID               string
+	EnableDiscordMessages      bool
+	SlackWebhookURL            string
+	SlackBotToken              string
+	SlackChannelIDs            []string
+	WebhookURLs                []string
+	WebhookSecret              string
+	WebhookDeadLetterPath      string
+	MatrixHomeserverURL        string
+	MatrixAccessToken          string
+	MatrixRoomIDs              []string
+	MattermostWebhookURL       string
+	NtfyServerURL              string
+	NtfyTopic                  string
+	NtfyAccessToken            string
+	GotifyServerURL            string
+	GotifyAppToken             string
+	SMTPHost                   string
+	SMTPPort                   int
+	SMTPUsername               string
+	SMTPPassword               string
+	SMTPFrom                   string
+	SMTPTo                     []string
+	SMTPDigestInterval         time.Duration
+	SMTPDigestPath             string
+	OutboxPath                 string
+	BinanceListingsRate        float64
+	BinanceAnnouncementsRate   float64
+	BinanceCatalogIDs          []int64
+	BinanceListingsMode        string
+	BinanceFallbackRate        float64
+	EnableBinanceStatuses      bool
+	BinanceStatusesRate        float64
+	BinanceWeightBudget        float64
+	BinanceEndpoints           []string
+	BinanceSources             []string
+	BinanceFuturesMarkets      []string
+	BinanceFuturesListingsRate float64
+	BinanceProducts            []string
+	BinanceProductsRate        float64
+	EnableCoinbaseListings     bool
+	CoinbaseListingsRate       float64
+	RestListingsExchanges      []string
+	RestListingsRate           float64
+	EnableTrader               bool
+	TraderQuoteAssets          map[string]float64
+	TraderOrderType            string
+	TraderSlippage             float64
+	TraderMaxWait              time.Duration
+	TraderExitMode             string
+	TraderTakeProfit           float64
+	TraderStopLoss             float64
+	TraderTrailingStop         float64
+	TraderMaxHoldTime          time.Duration
+	TraderMaxBurst             int
+	TraderPaperTrading         bool
+	TraderPaperFeeRate         float64
 }
 
 // GetEnvVars retrieves the programs environment variables.
@@ -76,11 +221,49 @@ func GetEnvVars() (envVars EnvVars) {
 		log.Fatalf("Error parsing TELEGRAM_CHAT_ID: %v", err)
 	}
 	discordChannelIDs := deleteEmpty(strings.Split(os.Getenv("DISCORD_CHANNEL_IDS"), ","))
+	telegramRoutes, err := parseTelegramRoutes(getEnvOrDefault("TELEGRAM_ROUTES", ""))
+	if err != nil {
+		log.Fatalf("Error parsing TELEGRAM_ROUTES: %v", err)
+	}
+	discordRoutes, err := parseRoutes(getEnvOrDefault("DISCORD_ROUTES", ""))
+	if err != nil {
+		log.Fatalf("Error parsing DISCORD_ROUTES: %v", err)
+	}
 	discordAppID := os.Getenv("DISCORD_APP_ID")
 	enableDiscordMessages, err := strconv.ParseBool(os.Getenv("ENABLE_DISCORD_MESSAGES"))
 	if err != nil {
 		log.Fatalf("Error parsing ENABLE_DISCORD_MESSAGES: %v", err)
 	}
+	slackWebhookURL := getEnvOrDefault("SLACK_WEBHOOK_URL", "")
+	slackBotToken := getEnvOrDefault("SLACK_BOT_TOKEN", "")
+	slackChannelIDs := deleteEmpty(strings.Split(getEnvOrDefault("SLACK_CHANNEL_IDS", ""), ","))
+	webhookURLs := deleteEmpty(strings.Split(getEnvOrDefault("WEBHOOK_URLS", ""), ","))
+	webhookSecret := getEnvOrDefault("WEBHOOK_SECRET", "")
+	webhookDeadLetterPath := getEnvOrDefault("WEBHOOK_DEAD_LETTER_PATH", "data/webhook_dead_letters.jsonl")
+	matrixHomeserverURL := getEnvOrDefault("MATRIX_HOMESERVER_URL", "")
+	matrixAccessToken := getEnvOrDefault("MATRIX_ACCESS_TOKEN", "")
+	matrixRoomIDs := deleteEmpty(strings.Split(getEnvOrDefault("MATRIX_ROOM_IDS", ""), ","))
+	mattermostWebhookURL := getEnvOrDefault("MATTERMOST_WEBHOOK_URL", "")
+	ntfyServerURL := getEnvOrDefault("NTFY_SERVER_URL", "https://ntfy.sh")
+	ntfyTopic := getEnvOrDefault("NTFY_TOPIC", "")
+	ntfyAccessToken := getEnvOrDefault("NTFY_ACCESS_TOKEN", "")
+	gotifyServerURL := getEnvOrDefault("GOTIFY_SERVER_URL", "")
+	gotifyAppToken := getEnvOrDefault("GOTIFY_APP_TOKEN", "")
+	smtpHost := getEnvOrDefault("SMTP_HOST", "")
+	smtpPort, err := strconv.Atoi(getEnvOrDefault("SMTP_PORT", "587"))
+	if err != nil {
+		log.Fatalf("Error parsing SMTP_PORT: %v", err)
+	}
+	smtpUsername := getEnvOrDefault("SMTP_USERNAME", "")
+	smtpPassword := getEnvOrDefault("SMTP_PASSWORD", "")
+	smtpFrom := getEnvOrDefault("SMTP_FROM", "")
+	smtpTo := deleteEmpty(strings.Split(getEnvOrDefault("SMTP_TO", ""), ","))
+	smtpDigestInterval, err := parseDigestInterval(getEnvOrDefault("SMTP_DIGEST", "immediate"))
+	if err != nil {
+		log.Fatalf("Error parsing SMTP_DIGEST: %v", err)
+	}
+	smtpDigestPath := getEnvOrDefault("SMTP_DIGEST_PATH", "data/email_digest.json")
+	outboxPath := getEnvOrDefault("OUTBOX_PATH", "data/outbox.json")
 	binance_listings_rate, err := strconv.ParseFloat(os.Getenv("BINANCE_LISTINGS_RATE"), 64)
 	if err != nil {
 		log.Fatalf("Error parsing BINANCE_LISTINGS_RATE: %v", err)
@@ -89,20 +272,203 @@ func GetEnvVars() (envVars EnvVars) {
 	if err != nil {
 		log.Fatalf("Error parsing BINANCE_LISTINGS_RATE: %v", err)
 	}
+	binanceCatalogIDs, err := parseIntList(getEnvOrDefault("BINANCE_ANNOUNCEMENT_CATALOGS", "48"))
+	if err != nil {
+		log.Fatalf("Error parsing BINANCE_ANNOUNCEMENT_CATALOGS: %v", err)
+	}
+	stateStore := strings.ToLower(getEnvOrDefault("STATE_STORE", "file"))
+	if stateStore != "file" && stateStore != "sqlite" {
+		log.Fatalf("Error parsing STATE_STORE: unknown store '%s'", stateStore)
+	}
+	sqliteDBPath := getEnvOrDefault("SQLITE_DB_PATH", "data/state.db")
+	binanceListingsMode := strings.ToLower(getEnvOrDefault("BINANCE_LISTINGS_MODE", "rest"))
+	if binanceListingsMode != "rest" && binanceListingsMode != "websocket" {
+		log.Fatalf("Error parsing BINANCE_LISTINGS_MODE: unknown mode '%s'", binanceListingsMode)
+	}
+	binanceFallbackRate, err := strconv.ParseFloat(getEnvOrDefault("BINANCE_LISTINGS_FALLBACK_RATE", "1"), 64)
+	if err != nil {
+		log.Fatalf("Error parsing BINANCE_LISTINGS_FALLBACK_RATE: %v", err)
+	}
+	enableBinanceStatuses, err := strconv.ParseBool(getEnvOrDefault("ENABLE_BINANCE_STATUSES", "false"))
+	if err != nil {
+		log.Fatalf("Error parsing ENABLE_BINANCE_STATUSES: %v", err)
+	}
+	binanceStatusesRate, err := strconv.ParseFloat(getEnvOrDefault("BINANCE_STATUSES_RATE", "0.2"), 64)
+	if err != nil {
+		log.Fatalf("Error parsing BINANCE_STATUSES_RATE: %v", err)
+	}
+	binanceWeightBudget, err := strconv.ParseFloat(getEnvOrDefault("BINANCE_WEIGHT_BUDGET", "0.8"), 64)
+	if err != nil || binanceWeightBudget <= 0 || binanceWeightBudget > 1 {
+		log.Fatalf("Error parsing BINANCE_WEIGHT_BUDGET: should be a fraction between 0 and 1")
+	}
+	binanceEndpoints := deleteEmpty(strings.Split(getEnvOrDefault("BINANCE_API_ENDPOINTS", "https://api.binance.com,https://api1.binance.com,https://api2.binance.com,https://api3.binance.com,https://api4.binance.com,https://data-api.binance.vision"), ","))
+	binanceSources := deleteEmpty(strings.Split(getEnvOrDefault("BINANCE_SOURCE_ADDRESSES", ""), ","))
+	binanceFuturesMarkets := deleteEmpty(strings.Split(strings.ToLower(getEnvOrDefault("BINANCE_FUTURES_MARKETS", "")), ","))
+	binanceFuturesListingsRate, err := strconv.ParseFloat(getEnvOrDefault("BINANCE_FUTURES_LISTINGS_RATE", "1"), 64)
+	if err != nil {
+		log.Fatalf("Error parsing BINANCE_FUTURES_LISTINGS_RATE: %v", err)
+	}
+	binanceProducts := deleteEmpty(strings.Split(strings.ToLower(getEnvOrDefault("BINANCE_PRODUCTS", "")), ","))
+	binanceProductsRate, err := strconv.ParseFloat(getEnvOrDefault("BINANCE_PRODUCTS_RATE", "0.05"), 64)
+	if err != nil {
+		log.Fatalf("Error parsing BINANCE_PRODUCTS_RATE: %v", err)
+	}
+	enableCoinbaseListings, err := strconv.ParseBool(getEnvOrDefault("ENABLE_COINBASE_LISTINGS", "false"))
+	if err != nil {
+		log.Fatalf("Error parsing ENABLE_COINBASE_LISTINGS: %v", err)
+	}
+	coinbaseListingsRate, err := strconv.ParseFloat(getEnvOrDefault("COINBASE_LISTINGS_RATE", "1"), 64)
+	if err != nil {
+		log.Fatalf("Error parsing COINBASE_LISTINGS_RATE: %v", err)
+	}
+	restListingsExchanges := deleteEmpty(strings.Split(getEnvOrDefault("REST_LISTINGS_EXCHANGES", ""), ","))
+	restListingsRate, err := strconv.ParseFloat(getEnvOrDefault("REST_LISTINGS_RATE", "1"), 64)
+	if err != nil {
+		log.Fatalf("Error parsing REST_LISTINGS_RATE: %v", err)
+	}
+
+	enableTrader, err := strconv.ParseBool(getEnvOrDefault("ENABLE_TRADER", "false"))
+	if err != nil {
+		log.Fatalf("Error parsing ENABLE_TRADER: %v", err)
+	}
+	traderQuoteAssets, err := parseNotionals(getEnvOrDefault("TRADER_QUOTE_ASSETS", ""))
+	if err != nil {
+		log.Fatalf("Error parsing TRADER_QUOTE_ASSETS: %v", err)
+	}
+	if enableTrader && len(traderQuoteAssets) == 0 {
+		log.Fatalf("Error parsing TRADER_QUOTE_ASSETS: no quote assets given")
+	}
+	traderOrderType := strings.ToLower(getEnvOrDefault("TRADER_ORDER_TYPE", "limit"))
+	if traderOrderType != "market" && traderOrderType != "limit" {
+		log.Fatalf("Error parsing TRADER_ORDER_TYPE: unknown order type '%s'", traderOrderType)
+	}
+	traderSlippage, err := strconv.ParseFloat(getEnvOrDefault("TRADER_SLIPPAGE", "0.05"), 64)
+	if err != nil || traderSlippage < 0 {
+		log.Fatalf("Error parsing TRADER_SLIPPAGE: should be a positive fraction")
+	}
+	traderMaxWait, err := time.ParseDuration(getEnvOrDefault("TRADER_MAX_WAIT", "10m"))
+	if err != nil {
+		log.Fatalf("Error parsing TRADER_MAX_WAIT: %v", err)
+	}
+	traderExitMode := strings.ToLower(getEnvOrDefault("TRADER_EXIT_MODE", ""))
+	if traderExitMode != "" && traderExitMode != "oco" && traderExitMode != "trailing" {
+		log.Fatalf("Error parsing TRADER_EXIT_MODE: unknown exit mode '%s'", traderExitMode)
+	}
+	traderTakeProfit, err := strconv.ParseFloat(getEnvOrDefault("TRADER_TAKE_PROFIT", "0"), 64)
+	if err != nil || traderTakeProfit < 0 {
+		log.Fatalf("Error parsing TRADER_TAKE_PROFIT: should be a positive fraction")
+	}
+	traderStopLoss, err := strconv.ParseFloat(getEnvOrDefault("TRADER_STOP_LOSS", "0"), 64)
+	if err != nil || traderStopLoss < 0 || traderStopLoss >= 1 {
+		log.Fatalf("Error parsing TRADER_STOP_LOSS: should be a positive fraction below 1")
+	}
+	traderTrailingStop, err := strconv.ParseFloat(getEnvOrDefault("TRADER_TRAILING_STOP", "0"), 64)
+	if err != nil || traderTrailingStop < 0 || traderTrailingStop >= 1 {
+		log.Fatalf("Error parsing TRADER_TRAILING_STOP: should be a positive fraction below 1")
+	}
+	if traderExitMode == "oco" && (traderTakeProfit == 0 || traderStopLoss == 0) {
+		log.Fatalf("Error parsing TRADER_EXIT_MODE: the 'oco' exit mode requires TRADER_TAKE_PROFIT and TRADER_STOP_LOSS")
+	}
+	if traderExitMode == "trailing" && traderTakeProfit == 0 && traderStopLoss == 0 && traderTrailingStop == 0 {
+		log.Fatalf("Error parsing TRADER_EXIT_MODE: the 'trailing' exit mode requires TRADER_TAKE_PROFIT, TRADER_STOP_LOSS or TRADER_TRAILING_STOP")
+	}
+	traderMaxHoldTime, err := time.ParseDuration(getEnvOrDefault("TRADER_MAX_HOLD_TIME", "0"))
+	if err != nil {
+		log.Fatalf("Error parsing TRADER_MAX_HOLD_TIME: %v", err)
+	}
+	traderMaxBurst, err := strconv.Atoi(getEnvOrDefault("TRADER_MAX_BURST", "3"))
+	if err != nil || traderMaxBurst < 0 {
+		log.Fatalf("Error parsing TRADER_MAX_BURST: should be a positive integer")
+	}
+	traderPaperTrading, err := strconv.ParseBool(getEnvOrDefault("TRADER_PAPER_TRADING", "false"))
+	if err != nil {
+		log.Fatalf("Error parsing TRADER_PAPER_TRADING: %v", err)
+	}
+	traderPaperFeeRate, err := strconv.ParseFloat(getEnvOrDefault("TRADER_PAPER_FEE_RATE", "0.001"), 64)
+	if err != nil || traderPaperFeeRate < 0 || traderPaperFeeRate >= 1 {
+		log.Fatalf("Error parsing TRADER_PAPER_FEE_RATE: should be a positive fraction below 1")
+	}
 
 	return EnvVars{
-		BinanceKey:               binanceKey,
-		BinanceSecret:            binanceSecret,
-		TelegramBotKey:           telegramBotKey,
-		TelegramChatID:           telegramChatID,
-		EnableTelegramMessage:    enableTelegramMessage,
-		DiscordBotKey:            discordBotKey,
-		DiscordChannelIDs:        discordChannelIDs,
-		DiscordAppID:             discordAppID,
-		EnableDiscordMessages:    enableDiscordMessages,
-		BinanceListingsRate:      binance_listings_rate,
-		BinanceAnnouncementsRate: binance_announcements_rate,
+		BinanceKey:                 binanceKey,
+		StateStore:                 stateStore,
+		SQLiteDBPath:               sqliteDBPath,
+		BinanceSecret:              binanceSecret,
+		TelegramBotKey:             telegramBotKey,
+		TelegramChatID:             telegramChatID,
+		TelegramRoutes:             telegramRoutes,
+		EnableTelegramMessage:      enableTelegramMessage,
+		DiscordBotKey:              discordBotKey,
+		DiscordChannelIDs:          discordChannelIDs,
+		DiscordRoutes:              discordRoutes,
+		DiscordAppID:               discordAppID,
+		EnableDiscordMessages:      enableDiscordMessages,
+		SlackWebhookURL:            slackWebhookURL,
+		SlackBotToken:              slackBotToken,
+		SlackChannelIDs:            slackChannelIDs,
+		WebhookURLs:                webhookURLs,
+		WebhookSecret:              webhookSecret,
+		WebhookDeadLetterPath:      webhookDeadLetterPath,
+		MatrixHomeserverURL:        matrixHomeserverURL,
+		MatrixAccessToken:          matrixAccessToken,
+		MatrixRoomIDs:              matrixRoomIDs,
+		MattermostWebhookURL:       mattermostWebhookURL,
+		NtfyServerURL:              ntfyServerURL,
+		NtfyTopic:                  ntfyTopic,
+		NtfyAccessToken:            ntfyAccessToken,
+		GotifyServerURL:            gotifyServerURL,
+		GotifyAppToken:             gotifyAppToken,
+		SMTPHost:                   smtpHost,
+		SMTPPort:                   smtpPort,
+		SMTPUsername:               smtpUsername,
+		SMTPPassword:               smtpPassword,
+		SMTPFrom:                   smtpFrom,
+		SMTPTo:                     smtpTo,
+		SMTPDigestInterval:         smtpDigestInterval,
+		SMTPDigestPath:             smtpDigestPath,
+		OutboxPath:                 outboxPath,
+		BinanceListingsRate:        binance_listings_rate,
+		BinanceAnnouncementsRate:   binance_announcements_rate,
+		BinanceCatalogIDs:          binanceCatalogIDs,
+		BinanceListingsMode:        binanceListingsMode,
+		BinanceFallbackRate:        binanceFallbackRate,
+		EnableBinanceStatuses:      enableBinanceStatuses,
+		BinanceStatusesRate:        binanceStatusesRate,
+		BinanceWeightBudget:        binanceWeightBudget,
+		BinanceEndpoints:           binanceEndpoints,
+		BinanceSources:             binanceSources,
+		BinanceFuturesMarkets:      binanceFuturesMarkets,
+		BinanceFuturesListingsRate: binanceFuturesListingsRate,
+		BinanceProducts:            binanceProducts,
+		BinanceProductsRate:        binanceProductsRate,
+		EnableCoinbaseListings:     enableCoinbaseListings,
+		CoinbaseListingsRate:       coinbaseListingsRate,
+		RestListingsExchanges:      restListingsExchanges,
+		RestListingsRate:           restListingsRate,
+		EnableTrader:               enableTrader,
+		TraderQuoteAssets:          traderQuoteAssets,
+		TraderOrderType:            traderOrderType,
+		TraderSlippage:             traderSlippage,
+		TraderMaxWait:              traderMaxWait,
+		TraderExitMode:             traderExitMode,
+		TraderTakeProfit:           traderTakeProfit,
+		TraderStopLoss:             traderStopLoss,
+		TraderTrailingStop:         traderTrailingStop,
+		TraderMaxHoldTime:          traderMaxHoldTime,
+		TraderMaxBurst:             traderMaxBurst,
+		TraderPaperTrading:         traderPaperTrading,
+		TraderPaperFeeRate:         traderPaperFeeRate,
+	}
+}
+
+// FormatAmount formats a asset amount or price with at most 8 decimals and without trailing zeros.
+func FormatAmount(amount float64) string {
+	formatted := strings.TrimRight(strconv.FormatFloat(amount, 'f', 8, 64), "0")
+	formatted = strings.TrimSuffix(formatted, ".")
+	if formatted == "-0" {
+		return "0"
 	}
+	return formatted
 }
 
 // HexColorToInt converts a hex color to int.
@@ -115,108 +481,48 @@ func HexColorToInt(color string) int {
 	return int(colorInt)
 }
 
-// CompareLists compares two lists of strings and returns the items that were removed/added in the new list.
-func CompareLists(oldList []string, newList []string) (removed bool, difference []string) {
-	if len(oldList) > len(newList) {
-		for _, s := range oldList {
-			if !contains(newList, s) {
-				difference = append(difference, s)
-			}
-		}
-
-		return true, difference
-	} else if len(oldList) < len(newList) {
-		// If the length of the new list is greater than the old list.
-		for _, s := range newList {
-			removed = false
-			if !contains(oldList, s) {
-				difference = append(difference, s)
-			}
-		}
-
-		return false, difference
+// DiffLists compares two lists of strings and returns the items that were added to and removed from the new list.
+func DiffLists(oldList []string, newList []string) (added []string, removed []string) {
+	oldSet := make(map[string]struct{}, len(oldList))
+	for _, s := range oldList {
+		oldSet[s] = struct{}{}
 	}
-
-	return false, []string{}
-}
-
-// RetrieveOldListings retrieves the old listed assets from the data folder.
-func RetrieveOldListings() (oldAssets []string) {
-	oldAssetsJson, err := os.ReadFile(ASSETS_FILE_PATH)
-	if err != nil {
-		if !os.IsNotExist(err) {
-			log.Fatalf("Error reading old listed assets from file '%s': %v", ASSETS_FILE_PATH, err)
+	newSet := make(map[string]struct{}, len(newList))
+	for _, s := range newList {
+		newSet[s] = struct{}{}
+		if _, ok := oldSet[s]; !ok {
+			added = append(added, s)
+			oldSet[s] = struct{}{} // NOTE: Prevents duplicates from being reported twice.
 		}
-	} else {
-		err = json.Unmarshal(oldAssetsJson, &oldAssets)
-		if err != nil {
-			log.Fatalf("Error unmarshalling old listed assets: %v", err)
+	}
+	for _, s := range oldList {
+		if _, ok := newSet[s]; !ok {
+			removed = append(removed, s)
+			newSet[s] = struct{}{}
 		}
-		log.Printf("Number of old listed assets: %d", len(oldAssets))
 	}
 
-	return oldAssets
-}
-
-// CreateDataFolder creates the data folder if it doesn't exist.
-func ensureDataFolderExistence() {
-	dataPath := path.Dir(ASSETS_FILE_PATH)
-	err := os.MkdirAll(dataPath, os.ModePerm)
-	if err != nil {
-		log.Fatalf("Error creating data folder: %v", err)
-	}
+	return added, removed
 }
 
-// StoreOldListings stores the old listed assets in the data folder.
-func StoreOldListings(assets []string) {
-	if len(assets) != 0 {
-		ensureDataFolderExistence()
-		assetsJson, err := json.Marshal(assets)
-		if err != nil {
-			log.Fatalf("Error marshalling listed assets: %v", err)
-		}
-		err = os.WriteFile(ASSETS_FILE_PATH, assetsJson, 0644)
-		if err != nil {
-			log.Fatalf("Error writing listed assets list to file '%s': %v", ASSETS_FILE_PATH, err)
-		}
-	}
+// CreateBinanceURL returns the assets Binance URL.
+func CreateBinanceURL(assetName string) string {
+	return "https://www.binance.com/en/trade/" + assetName
 }
 
-// RetrieveOldAnnouncements retrieves the old announcements from the data folder.
-func RetrieveOldAnnouncements() (oldAnnouncements []string) {
-	oldAssetsJson, err := os.ReadFile(ANNOUNCEMENTS_FILE_PATH)
-	if err != nil {
-		if !os.IsNotExist(err) {
-			log.Fatalf("Error reading old announcements from file '%s': %v", ANNOUNCEMENTS_FILE_PATH, err)
-		}
-	} else {
-		err = json.Unmarshal(oldAssetsJson, &oldAnnouncements)
-		if err != nil {
-			log.Fatalf("Error unmarshalling old announcements: %v", err)
-		}
-	}
-
-	return oldAnnouncements
+// CreateBinanceFuturesURL returns the USDⓈ-M futures contract Binance URL.
+func CreateBinanceFuturesURL(symbol string) string {
+	return "https://www.binance.com/en/futures/" + symbol
 }
 
-// StoreOldAnnouncements store the old assets in the data folder.
-func StoreOldAnnouncements(announcements []string) {
-	if len(announcements) != 0 {
-		ensureDataFolderExistence()
-		announcementsJson, err := json.Marshal(announcements)
-		if err != nil {
-			log.Fatalf("Error marshalling announcements list: %v", err)
-		}
-		err = os.WriteFile(ANNOUNCEMENTS_FILE_PATH, announcementsJson, 0644)
-		if err != nil {
-			log.Fatalf("Error writing announcements list to file '%s': %v", ANNOUNCEMENTS_FILE_PATH, err)
-		}
-	}
+// CreateBinanceDeliveryURL returns the COIN-M futures contract Binance URL.
+func CreateBinanceDeliveryURL(symbol string) string {
+	return "https://www.binance.com/en/delivery/" + strings.ToLower(symbol)
 }
 
-// CreateBinanceURL returns the assets Binance URL.
-func CreateBinanceURL(assetName string) string {
-	return "https://www.binance.com/en/trade/" + assetName
+// CreateCoinbaseURL returns the trading pair Coinbase URL.
+func CreateCoinbaseURL(productID string) string {
+	return "https://www.coinbase.com/advanced-trade/spot/" + productID
 }
 
 // CreateBinanceArticleURL returns the binance article URL.
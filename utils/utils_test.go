@@ -4,6 +4,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 )
 
 // TestGetEnvVar tests the GetEnvVar function.
@@ -15,6 +16,99 @@ func TestDeleteEmpty(t *testing.T) {
 	}
 }
 
+// TestGetEnvOrDefault tests the getEnvOrDefault function.
+func TestGetEnvOrDefault(t *testing.T) {
+	t.Setenv("CLS_TEST_ENV_VAR", "hello")
+	if r := getEnvOrDefault("CLS_TEST_ENV_VAR", "world"); r != "hello" {
+		t.Errorf("Expected %s, got %s", "hello", r)
+	}
+	if r := getEnvOrDefault("CLS_TEST_UNSET_ENV_VAR", "world"); r != "world" {
+		t.Errorf("Expected %s, got %s", "world", r)
+	}
+}
+
+// TestParseRoutes tests the parseRoutes function.
+func TestParseRoutes(t *testing.T) {
+	routes, err := parseRoutes("listing:123|456, Delisting:789")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(routes["listing"]) != 2 || routes["listing"][1] != "456" {
+		t.Errorf("Expected %v, got %v", []string{"123", "456"}, routes["listing"])
+	}
+	if len(routes["delisting"]) != 1 || routes["delisting"][0] != "789" {
+		t.Errorf("Expected %v, got %v", []string{"789"}, routes["delisting"])
+	}
+
+	routes, err = parseRoutes("")
+	if err != nil || len(routes) != 0 {
+		t.Errorf("Expected no routes and no error, got %v and %v", routes, err)
+	}
+	if _, err = parseRoutes("listing"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if _, err = parseRoutes("listing:"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestParseTelegramRoutes tests the parseTelegramRoutes function.
+func TestParseTelegramRoutes(t *testing.T) {
+	routes, err := parseTelegramRoutes("listing:-100123|-100456")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(routes["listing"]) != 2 || routes["listing"][0] != -100123 {
+		t.Errorf("Expected %v, got %v", []int64{-100123, -100456}, routes["listing"])
+	}
+	if _, err = parseTelegramRoutes("listing:abc"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestParseIntList tests the parseIntList function.
+func TestParseIntList(t *testing.T) {
+	ints, err := parseIntList("48, 161")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ints) != 2 || ints[0] != 48 || ints[1] != 161 {
+		t.Errorf("Expected %v, got %v", []int64{48, 161}, ints)
+	}
+	if _, err = parseIntList("48,abc"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+// TestParseNotionals tests the parseNotionals function.
+func TestParseNotionals(t *testing.T) {
+	notionals, err := parseNotionals("usdt:100, FDUSD:50.5")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notionals) != 2 || notionals["USDT"] != 100 || notionals["FDUSD"] != 50.5 {
+		t.Errorf("Expected %v, got %v", map[string]float64{"USDT": 100, "FDUSD": 50.5}, notionals)
+	}
+	for _, list := range []string{"USDT", "USDT:abc", "USDT:-1"} {
+		if _, err = parseNotionals(list); err == nil {
+			t.Errorf("Expected error for '%s', got nil", list)
+		}
+	}
+}
+
+// TestParseDigestInterval tests the parseDigestInterval function.
+func TestParseDigestInterval(t *testing.T) {
+	if interval, err := parseDigestInterval("immediate"); err != nil || interval != 0 {
+		t.Errorf("Expected %v, got %v (%v)", time.Duration(0), interval, err)
+	}
+	if interval, err := parseDigestInterval("Daily"); err != nil || interval != 24*time.Hour {
+		t.Errorf("Expected %v, got %v (%v)", 24*time.Hour, interval, err)
+	}
+	if _, err := parseDigestInterval("weekly"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
 // TestContains tests the Contains function.
 func TestContains(t *testing.T) {
 	s := []string{"hello", "world"}
@@ -26,6 +120,16 @@ func TestContains(t *testing.T) {
 	}
 }
 
+// TestFormatAmount tests the FormatAmount function.
+func TestFormatAmount(t *testing.T) {
+	tests := map[float64]string{1.7800000000000011: "1.78", 100: "100", 0.000000001: "0", -2.5: "-2.5"}
+	for amount, expected := range tests {
+		if formatted := FormatAmount(amount); formatted != expected {
+			t.Errorf("Expected %s, got %s", expected, formatted)
+		}
+	}
+}
+
 // TestHexColorToInt tests the HexColorToInt function.
 func TestHexColorToInt(t *testing.T) {
 	color := "#ff0000"
@@ -43,32 +147,50 @@ func TestHexColorToInt(t *testing.T) {
 	}
 }
 
-// TestCompareLists tests the CompareLists function.
-func TestCompareLists(t *testing.T) {
+// TestDiffLists tests the DiffLists function.
+func TestDiffLists(t *testing.T) {
 	list1 := []string{"hello", "world"}
 	list2 := []string{"hello", "world"}
-	r, _ := CompareLists(list1, list2)
-	if r {
-		t.Errorf("Expected false, got true")
+	added, removed := DiffLists(list1, list2)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected no differences, got %v and %v", added, removed)
 	}
 
 	list1 = []string{"hello", "world"}
 	list2 = []string{"hello", "world", "test"}
-	r, diff := CompareLists(list1, list2)
-	if r {
-		t.Errorf("Expected false, got true")
+	added, removed = DiffLists(list1, list2)
+	if len(added) != 1 || added[0] != "test" {
+		t.Errorf("Expected %v, got %v", []string{"test"}, added)
 	}
-	if len(diff) != 1 {
-		t.Errorf("Expected length of 1, got %d", len(diff))
+	if len(removed) != 0 {
+		t.Errorf("Expected length of 0, got %d", len(removed))
 	}
+
 	list1 = []string{"hello", "world", "test"}
 	list2 = []string{"hello", "world"}
-	r, diff = CompareLists(list1, list2)
-	if !r {
-		t.Errorf("Expected true, got false")
+	added, removed = DiffLists(list1, list2)
+	if len(added) != 0 {
+		t.Errorf("Expected length of 0, got %d", len(added))
+	}
+	if len(removed) != 1 || removed[0] != "test" {
+		t.Errorf("Expected %v, got %v", []string{"test"}, removed)
+	}
+
+	// Simultaneous listing and de-listing.
+	list1 = []string{"hello", "world", "test"}
+	list2 = []string{"hello", "foo", "test"}
+	added, removed = DiffLists(list1, list2)
+	if len(added) != 1 || added[0] != "foo" {
+		t.Errorf("Expected %v, got %v", []string{"foo"}, added)
+	}
+	if len(removed) != 1 || removed[0] != "world" {
+		t.Errorf("Expected %v, got %v", []string{"world"}, removed)
 	}
-	if len(diff) != 1 {
-		t.Errorf("Expected length of 1, got %d", len(diff))
+
+	// Duplicates are reported once.
+	added, removed = DiffLists([]string{"a", "a"}, []string{"b", "b"})
+	if len(added) != 1 || len(removed) != 1 {
+		t.Errorf("Expected length of 1 and 1, got %d and %d", len(added), len(removed))
 	}
 }
 
@@ -81,6 +203,15 @@ func TestCreateBinanceURL(t *testing.T) {
 	}
 }
 
+// TestCreateCoinbaseURL tests the CreateCoinbaseURL function.
+func TestCreateCoinbaseURL(t *testing.T) {
+	expected := "https://www.coinbase.com/advanced-trade/spot/BTC-USD"
+	r := CreateCoinbaseURL("BTC-USD")
+	if r != expected {
+		t.Errorf("Expected %s, got %s", expected, r)
+	}
+}
+
 // TestCreateBinanceArticleUrl tests the CreateBinanceArticleUrl function.
 func TestCreateBinanceArticleUrl(t *testing.T) {
 	expected := "https://www.binance.com/en/support/announcement/article-48"